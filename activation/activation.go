@@ -1,6 +1,11 @@
 package activation
 
-import "math"
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+)
 
 func Tanh(x float64) float64 {
 	return math.Tanh(x)
@@ -32,3 +37,117 @@ const (
 	Relu_T
 	LeakyRelu_T
 )
+
+type entry struct {
+	name string
+	fn   func(float64) float64
+}
+
+var (
+	mu        sync.RWMutex
+	functions = []entry{
+		Tanh_T:      {"Tanh", Tanh},
+		Sigmoid_T:   {"Sigmoid", Sigmoid},
+		Relu_T:      {"Relu", Relu},
+		LeakyRelu_T: {"LeakyRelu", LeakyRelu},
+	}
+)
+
+// Register adds fn to the activation functions under name, so genomes using
+// it can be saved and loaded, and returns its value. Registered functions are
+// also picked by the activation mutation. It panics if name is already taken,
+// or if fn is: functions are told apart by their code, so two closures made
+// by the same function literal are the same function to Of, and only one of
+// them can be registered.
+func Register(name string, fn func(float64) float64) ActivationFunction {
+	mu.Lock()
+	defer mu.Unlock()
+	ptr := reflect.ValueOf(fn).Pointer()
+	for _, e := range functions {
+		if e.name == name {
+			panic("activation: Register called twice for " + name)
+		}
+		if reflect.ValueOf(e.fn).Pointer() == ptr {
+			panic("activation: " + name + " is already registered as " + e.name)
+		}
+	}
+	functions = append(functions, entry{name, fn})
+	return ActivationFunction(len(functions) - 1)
+}
+
+// Lookup returns the activation function registered under name.
+func Lookup(name string) (ActivationFunction, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	for i, e := range functions {
+		if e.name == name {
+			return ActivationFunction(i), true
+		}
+	}
+	return 0, false
+}
+
+// Name returns the name of v, which is either an ActivationFunction or a
+// func(float64) float64 that has been registered.
+func Name(v any) (string, bool) {
+	switch v := v.(type) {
+	case ActivationFunction:
+		mu.RLock()
+		defer mu.RUnlock()
+		if v < 0 || int(v) >= len(functions) {
+			return "", false
+		}
+		return functions[v].name, true
+	case func(float64) float64:
+		a, ok := Of(v)
+		if !ok {
+			return "", false
+		}
+		return Name(a)
+	}
+	return "", false
+}
+
+// All returns every activation function, registered ones included, in the
+// order of their values.
+func All() []ActivationFunction {
+	mu.RLock()
+	defer mu.RUnlock()
+	all := make([]ActivationFunction, len(functions))
+	for i := range all {
+		all[i] = ActivationFunction(i)
+	}
+	return all
+}
+
+// Func returns the function a stands for.
+func (a ActivationFunction) Func() func(float64) float64 {
+	mu.RLock()
+	defer mu.RUnlock()
+	return functions[a].fn
+}
+
+func (a ActivationFunction) String() string {
+	if name, ok := Name(a); ok {
+		return name
+	}
+	return fmt.Sprintf("ActivationFunction(%d)", int(a))
+}
+
+// Of returns the value standing for fn, which is only found when fn is one
+// of the functions of this package or has been registered. Closures are
+// matched by their code, see Register.
+func Of(fn func(float64) float64) (ActivationFunction, bool) {
+	if fn == nil {
+		return 0, false
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	ptr := reflect.ValueOf(fn).Pointer()
+	for i, f := range functions {
+		if reflect.ValueOf(f.fn).Pointer() == ptr {
+			return ActivationFunction(i), true
+		}
+	}
+	return 0, false
+}
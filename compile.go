@@ -0,0 +1,124 @@
+package sometinyai
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/dominikbraun/graph"
+)
+
+// plan is the flattened form of a Genome used by ForwardPropagation. Every
+// node gets a dense slot, and every non-input node is evaluated in
+// topological order from a contiguous run of its incoming edges.
+type plan struct {
+	size    int                     // Number of dense node slots
+	targets []int                   // Slot written by each evaluation step
+	acts    []func(float64) float64 // Activation of each evaluation step
+	offsets []int                   // Incoming edges of targets[i] are offsets[i]:offsets[i+1]
+	sources []int
+	weights []float64
+	biases  []float64
+	outputs []int
+}
+
+// Compile flattens the topological order and the incoming edges of the genome
+// into contiguous slices. ForwardPropagation compiles on first use, and every
+// mutation discards the plan, so calling it explicitly is only needed to pay
+// the cost up front. Compiling a genome that already is compiled does nothing.
+func (g *Genome) Compile() error {
+	if g.plan != nil {
+		return nil
+	}
+	var order []int
+	var err error
+	if g.recurrent {
+		order, err = g.recurrentOrder()
+	} else {
+		order, err = graph.TopologicalSort(g.graph)
+	}
+	if err != nil {
+		return err
+	}
+	adj, err := g.graph.AdjacencyMap()
+	if err != nil {
+		return err
+	}
+
+	// Inputs keep their own index so the caller's values can be copied in directly
+	slot := make(map[int]int, len(order))
+	for i := range g.input {
+		slot[i] = i
+	}
+	for _, node := range order {
+		if _, ok := slot[node]; !ok {
+			slot[node] = len(slot)
+		}
+	}
+
+	incoming := make(map[int][]graph.Edge[int], len(order))
+	for _, targets := range adj {
+		for target, edge := range targets {
+			incoming[target] = append(incoming[target], edge)
+		}
+	}
+	// A fixed summation order keeps the outputs identical across runs
+	for _, edges := range incoming {
+		slices.SortFunc(edges, func(a, b graph.Edge[int]) int { return cmp.Compare(a.Source, b.Source) })
+	}
+
+	p := &plan{
+		size:    len(slot),
+		targets: make([]int, 0, len(order)),
+		offsets: make([]int, 1, len(order)+1),
+		outputs: make([]int, g.output),
+	}
+	for _, node := range order {
+		if node < g.input {
+			continue
+		}
+		for _, edge := range incoming[node] {
+			data := edge.Properties.Data.(*EdgeConnectionData)
+			p.sources = append(p.sources, slot[edge.Source])
+			p.weights = append(p.weights, data.weight)
+			p.biases = append(p.biases, data.bias)
+		}
+		p.targets = append(p.targets, slot[node])
+		p.acts = append(p.acts, g.nodeActivation(node))
+		p.offsets = append(p.offsets, len(p.sources))
+	}
+	for i := range p.outputs {
+		p.outputs[i] = slot[g.input+i]
+	}
+
+	g.plan = p
+	return nil
+}
+
+// run evaluates the plan over values, which holds one entry per slot. Slots
+// read before they are written this pass keep what values had, the previous
+// state for a recurrent genome.
+func (p *plan) run(values, input []float64) []float64 {
+	copy(values, input)
+	for i, target := range p.targets {
+		var sum float64
+		for e := p.offsets[i]; e < p.offsets[i+1]; e++ {
+			sum += values[p.sources[e]]*p.weights[e] + p.biases[e]
+		}
+		values[target] = p.acts[i](sum)
+	}
+
+	outputValues := make([]float64, len(p.outputs))
+	for i, s := range p.outputs {
+		outputValues[i] = values[s]
+	}
+	return outputValues
+}
+
+// invalidate drops everything derived from the graph. Every operation that
+// touches the structure or the edge data has to call it.
+func (g *Genome) invalidate() {
+	g.order = nil
+	g.adjacency = nil
+	g.plan = nil
+	g.state = nil
+}
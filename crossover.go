@@ -0,0 +1,117 @@
+package sometinyai
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/dominikbraun/graph"
+
+	"github.com/matwate/sometinyai/activation"
+)
+
+// gene is a connection of a genome, as seen when aligning two genomes.
+type gene struct {
+	from, to int
+	data     *EdgeConnectionData
+}
+
+// genes returns the connections of the genome sorted by innovation number.
+func (g *Genome) genes() []gene {
+	adj, _ := g.graph.AdjacencyMap()
+	var genes []gene
+	for source, targets := range adj {
+		for target, edge := range targets {
+			genes = append(genes, gene{source, target, edge.Properties.Data.(*EdgeConnectionData)})
+		}
+	}
+	slices.SortFunc(genes, func(a, b gene) int {
+		return cmp.Compare(a.data.innovation, b.data.innovation)
+	})
+	return genes
+}
+
+// Crossover returns a child of a and b. Matching genes are inherited from a
+// random parent, disjoint and excess genes from the fitter one, which is a
+// unless fitterParent says otherwise. Both parents should share an
+// InnovationTracker, and they are left untouched. The choices are drawn from
+// the random source of the fitter parent, which the child shares.
+func Crossover(a, b *Genome, fitterParent ...*Genome) *Genome {
+	if len(fitterParent) > 0 && fitterParent[0] == b {
+		a, b = b, a
+	}
+
+	other := map[int]gene{}
+	for _, gn := range b.genes() {
+		other[gn.data.innovation] = gn
+	}
+
+	adj, _ := a.graph.AdjacencyMap()
+	gr := newGraph(a.recurrent)
+	for node := range adj {
+		gr.AddVertex(node)
+	}
+
+	for _, gn := range a.genes() {
+		inherited := gn
+		if match, ok := other[gn.data.innovation]; ok && a.Rand().IntN(2) == 0 {
+			inherited = match
+		}
+		if err := addGene(gr, inherited, a.recurrent); err != nil && inherited != gn {
+			// The other parent's version does not fit in this structure
+			addGene(gr, gn, a.recurrent)
+		}
+	}
+
+	// Nodes take their activation from the fitter parent when it has them
+	activations := map[int]activation.ActivationFunction{}
+	childAdj, _ := gr.AdjacencyMap()
+	for node := range childAdj {
+		if act, ok := a.activations[node]; ok {
+			activations[node] = act
+		} else if act, ok := b.activations[node]; ok {
+			activations[node] = act
+		}
+	}
+
+	return &Genome{
+		graph:              gr,
+		input:              a.input,
+		output:             a.output,
+		hidden:             len(childAdj) - a.input - a.output,
+		activationFunction: a.activationFunction,
+		activations:        activations,
+		fixedOutputs:       a.fixedOutputs,
+		innovations:        a.innovations,
+		recurrent:          a.recurrent,
+		rng:                a.rng,
+	}
+}
+
+// addGene adds the connection to gr, along with any node it is missing. When
+// the connection cannot be added, or would close a cycle in a feed forward
+// graph, the nodes are left out too.
+func addGene(gr graph.Graph[int, int], gn gene, recurrent bool) error {
+	var added []int
+	for _, node := range []int{gn.from, gn.to} {
+		if _, err := gr.Vertex(node); err != nil {
+			gr.AddVertex(node)
+			added = append(added, node)
+		}
+	}
+	var err error
+	if cycle, _ := graph.CreatesCycle(gr, gn.from, gn.to); cycle && !recurrent {
+		err = graph.ErrEdgeCreatesCycle
+	} else {
+		err = gr.AddEdge(gn.from, gn.to, graph.EdgeData(&EdgeConnectionData{
+			weight:     gn.data.weight,
+			bias:       gn.data.bias,
+			innovation: gn.data.innovation,
+		}))
+	}
+	if err != nil {
+		for _, node := range added {
+			gr.RemoveVertex(node)
+		}
+	}
+	return err
+}
@@ -2,9 +2,12 @@ package sometinyai
 
 import (
 	"fmt"
+	"maps"
 	"math/rand/v2"
 
 	"github.com/dominikbraun/graph"
+
+	"github.com/matwate/sometinyai/activation"
 )
 
 type Genome struct {
@@ -14,15 +17,65 @@ type Genome struct {
 	output             int
 	hidden             int
 	adjacency          map[int]map[int]graph.Edge[int]
-	activationFunction func(float64) float64 // This will be used for ALL nodes
+	plan               *plan                 // Compiled forward pass, nil until Compile
+	activationFunction func(float64) float64 // Used by the nodes without an activation of their own
+	activations        map[int]activation.ActivationFunction
+	fixedOutputs       bool // ChangeActivation leaves the output nodes alone
+	innovations        *InnovationTracker
+	recurrent          bool       // Cycles and self loops are allowed
+	state              []float64  // Node values kept between calls to Step, by plan slot
+	rng                *rand.Rand // Source of every random choice, see Rand
 }
 
 type EdgeConnectionData struct {
 	weight, bias float64
+	innovation   int
+}
+
+// Gene is a connection of a genome, identified across genomes by its
+// innovation number.
+type Gene struct {
+	Innovation   int
+	From, To     int
+	Weight, Bias float64
+}
+
+type GenomeOption func(*Genome)
+
+// WithInnovationTracker makes the genome take its historical markings from
+// t, which should be shared by every genome of the population.
+func WithInnovationTracker(t *InnovationTracker) GenomeOption {
+	return func(g *Genome) { g.innovations = t }
 }
 
-func NewGenome(x, y int, activation func(float64) float64) *Genome {
-	g := graph.New(graph.IntHash, graph.Directed(), graph.Acyclic())
+// OutputActivation gives every output node the activation a, and keeps
+// ChangeActivation from changing it.
+func OutputActivation(a activation.ActivationFunction) GenomeOption {
+	return func(g *Genome) {
+		for i := range g.output {
+			g.activations[g.input+i] = a
+		}
+		g.fixedOutputs = true
+	}
+}
+
+func NewGenome(x, y int, act func(float64) float64, opts ...GenomeOption) *Genome {
+	genome := &Genome{
+		order:              nil,
+		input:              x,
+		output:             y,
+		hidden:             0,
+		activationFunction: act,
+		activations:        map[int]activation.ActivationFunction{},
+	}
+	for _, opt := range opts {
+		opt(genome)
+	}
+	if genome.innovations == nil {
+		genome.innovations = NewInnovationTracker(x, y)
+	}
+
+	g := newGraph(genome.recurrent)
 	for i := range x {
 		g.AddVertex(i)
 	}
@@ -31,44 +84,53 @@ func NewGenome(x, y int, activation func(float64) float64) *Genome {
 	}
 	for i := range x {
 		for j := range y {
-			g.AddEdge(i, j+x, graph.EdgeData(NewEdgeConnectionData(-1, -1)))
+			data := newEdgeConnectionData(genome.Rand(), -1, -1)
+			data.innovation = genome.innovations.connection(i, j+x)
+			g.AddEdge(i, j+x, graph.EdgeData(data))
 		}
 	}
-	return &Genome{
-		graph:              g,
-		order:              nil,
-		input:              x,
-		output:             y,
-		hidden:             0,
-		activationFunction: activation,
-	}
+	genome.graph = g
+	return genome
 }
 
-func (g *Genome) AddNode() {
-	// Get the number of nodes
-	nodeCount, _ := g.graph.Order()
-
-	// Add a new node
-	g.graph.AddVertex(nodeCount)
+// AddNode adds an unconnected hidden node and returns its id.
+func (g *Genome) AddNode() int {
+	node := g.innovations.node()
+	g.graph.AddVertex(node)
 
 	g.hidden++
+	g.invalidate()
+	return node
 }
 
 func (g *Genome) AddEdge(from, to int, data *EdgeConnectionData) {
-	g.graph.AddEdge(from, to, graph.EdgeData(data))
-	g.invalidateAdjacency()
-	adj, _ := g.graph.AdjacencyMap()
-	weight := adj[from][to].Properties.Data.(*EdgeConnectionData).weight
-	bias := adj[from][to].Properties.Data.(*EdgeConnectionData).bias
-	fmt.Printf("Added edge from %d to %d with weight %f and bias %f\n", from, to, weight, bias)
+	data.innovation = g.innovations.connection(from, to)
+	if err := g.graph.AddEdge(from, to, graph.EdgeData(data)); err != nil {
+		Logger().Debug("edge not added", "from", from, "to", to, "err", err)
+		return
+	}
+	g.invalidate()
+	Logger().Debug("added edge", "from", from, "to", to, "weight", data.weight, "bias", data.bias)
 }
 
-func (g *Genome) invalidateAdjacency() {
-	g.adjacency = nil
-	g.adjacency, _ = g.graph.AdjacencyMap()
+func (g *Genome) ForwardPropagation(input ...float64) []float64 {
+	// Check for correct input length
+	if len(input) != g.input {
+		panic(fmt.Sprintf("Expected %d inputs, got %d", g.input, len(input)))
+	}
+
+	if g.plan == nil {
+		if err := g.Compile(); err != nil {
+			panic(err)
+		}
+	}
+	return g.plan.run(make([]float64, g.plan.size), input)
 }
 
-func (g *Genome) ForwardPropagation(input ...float64) []float64 {
+// forwardPropagationGraph evaluates the genome straight from the graph,
+// scanning the adjacency map for the incoming edges of every node. It is the
+// reference the compiled plan is checked and benchmarked against.
+func (g *Genome) forwardPropagationGraph(input ...float64) []float64 {
 	// Check for correct input length
 	if len(input) != g.input {
 		panic(fmt.Sprintf("Expected %d inputs, got %d", g.input, len(input)))
@@ -81,8 +143,11 @@ func (g *Genome) ForwardPropagation(input ...float64) []float64 {
 	}
 	ordering := g.order
 
-	// Initialize node values
-	nodeCount, _ := g.graph.Order()
+	// Initialize node values, node ids are not contiguous once hidden nodes exist
+	nodeCount := g.input + g.output
+	for _, node := range ordering {
+		nodeCount = max(nodeCount, node+1)
+	}
 	nodeValues := make([]float64, nodeCount)
 
 	// Set input node values
@@ -116,7 +181,7 @@ func (g *Genome) ForwardPropagation(input ...float64) []float64 {
 		}
 
 		// Apply activation function (e.g., tanh)
-		nodeValues[node] = g.activationFunction(sum)
+		nodeValues[node] = g.nodeActivation(node)(sum)
 	}
 
 	// Collect output values
@@ -138,11 +203,15 @@ func (g *Genome) ForwardPropagationBatched(input ...[]float64) [][]float64 {
 }
 
 func NewEdgeConnectionData(weight, bias float64) *EdgeConnectionData {
+	return newEdgeConnectionData(globalRand, weight, bias)
+}
+
+func newEdgeConnectionData(r *rand.Rand, weight, bias float64) *EdgeConnectionData {
 	if weight < 0 {
-		weight = rand.NormFloat64()
+		weight = r.NormFloat64()
 	}
 	if bias < 0 {
-		bias = rand.NormFloat64()
+		bias = r.NormFloat64()
 	}
 	return &EdgeConnectionData{
 		weight: weight,
@@ -159,9 +228,12 @@ func (g *Genome) Print() {
 	}
 }
 
-func (g *Genome) Copy() *Genome {
+// Copy returns a deep copy of the genome, which shares its innovation tracker
+// and random source unless opts replace them. A tracker given with
+// WithInnovationTracker is kept from handing out the markings the copy uses.
+func (g *Genome) Copy(opts ...GenomeOption) *Genome {
 	adj, _ := g.graph.AdjacencyMap()
-	newGraph := graph.New(graph.IntHash, graph.Directed(), graph.Acyclic())
+	newGraph := newGraph(g.recurrent)
 
 	// Copy vertices
 	for source := range adj {
@@ -175,8 +247,9 @@ func (g *Genome) Copy() *Genome {
 
 			// Deep copy of EdgeConnectionData
 			newData := &EdgeConnectionData{
-				weight: oldData.weight,
-				bias:   oldData.bias,
+				weight:     oldData.weight,
+				bias:       oldData.bias,
+				innovation: oldData.innovation,
 			}
 
 			// Add the edge with the copied data
@@ -184,11 +257,76 @@ func (g *Genome) Copy() *Genome {
 		}
 	}
 
-	return &Genome{
+	c := &Genome{
 		graph:              newGraph,
 		input:              g.input,
 		output:             g.output,
 		hidden:             g.hidden,
 		activationFunction: g.activationFunction,
+		activations:        maps.Clone(g.activations),
+		fixedOutputs:       g.fixedOutputs,
+		innovations:        g.innovations,
+		recurrent:          g.recurrent,
+		rng:                g.rng,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.innovations != g.innovations {
+		for source, targets := range adj {
+			c.innovations.observe(0, source)
+			for _, edge := range targets {
+				c.innovations.observe(edge.Properties.Data.(*EdgeConnectionData).innovation, 0)
+			}
+		}
+	}
+	return c
+}
+
+// nodeActivation returns the activation function of the node.
+func (g *Genome) nodeActivation(node int) func(float64) float64 {
+	if a, ok := g.activations[node]; ok {
+		return a.Func()
+	}
+	return g.activationFunction
+}
+
+// Activation returns the activation of the node, and false when the node
+// uses the genome's default function and that is not one of the activation
+// package.
+func (g *Genome) Activation(node int) (activation.ActivationFunction, bool) {
+	if a, ok := g.activations[node]; ok {
+		return a, true
+	}
+	return activation.Of(g.activationFunction)
+}
+
+func newGraph(recurrent bool) graph.Graph[int, int] {
+	if recurrent {
+		return graph.New(graph.IntHash, graph.Directed())
+	}
+	return graph.New(graph.IntHash, graph.Directed(), graph.Acyclic())
+}
+
+// Genes returns the connections of the genome sorted by innovation number.
+func (g *Genome) Genes() []Gene {
+	genes := g.genes()
+	out := make([]Gene, len(genes))
+	for i, gn := range genes {
+		out[i] = Gene{
+			Innovation: gn.data.innovation,
+			From:       gn.from,
+			To:         gn.to,
+			Weight:     gn.data.weight,
+			Bias:       gn.data.bias,
+		}
+	}
+	return out
+}
+
+// Size returns the number of nodes and connections of the genome.
+func (g *Genome) Size() (nodes, connections int) {
+	nodes, _ = g.graph.Order()
+	connections, _ = g.graph.Size()
+	return nodes, connections
 }
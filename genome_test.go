@@ -0,0 +1,87 @@
+package sometinyai
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/matwate/sometinyai/activation"
+)
+
+func benchmarkGenome(b *testing.B, hidden int) (*Genome, []float64) {
+	b.Helper()
+	g := NewGenome(16, 4, activation.Tanh)
+	for g.hidden < hidden {
+		g.SplitConnection()
+	}
+	input := make([]float64, g.input)
+	for i := range input {
+		input[i] = float64(i) / float64(len(input))
+	}
+
+	want := g.forwardPropagationGraph(input...)
+	got := g.ForwardPropagation(input...)
+	for i := range want {
+		if math.Abs(want[i]-got[i]) > 1e-9 {
+			b.Fatalf("output %d: compiled %f, graph %f", i, got[i], want[i])
+		}
+	}
+	return g, input
+}
+
+func TestCompileMatchesGraph(t *testing.T) {
+	tests := []struct {
+		name           string
+		inputs, output int
+		act            func(float64) float64
+	}{
+		{"sigmoid", 2, 1, activation.Sigmoid},
+		{"tanh", 5, 3, activation.Tanh},
+		{"relu", 8, 2, activation.Relu},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGenome(tt.inputs, tt.output, tt.act)
+			g.SetRand(rand.New(rand.NewPCG(1, 2)))
+			input := make([]float64, tt.inputs)
+			for round := range 50 {
+				for i := range input {
+					input[i] = g.Rand().Float64()*2 - 1
+				}
+				// The plan compiled by the previous round must have been dropped
+				got := g.ForwardPropagation(input...)
+				want := g.forwardPropagationGraph(input...)
+				for i := range want {
+					if math.Abs(want[i]-got[i]) > 1e-9 {
+						t.Fatalf("round %d, output %d: compiled %f, graph %f", round, i, got[i], want[i])
+					}
+				}
+				g.Mutate(1)
+			}
+		})
+	}
+}
+
+func benchmarkForwardPropagation(b *testing.B, hidden int) {
+	g, input := benchmarkGenome(b, hidden)
+	b.ResetTimer()
+	for range b.N {
+		g.ForwardPropagation(input...)
+	}
+}
+
+func benchmarkForwardPropagationGraph(b *testing.B, hidden int) {
+	g, input := benchmarkGenome(b, hidden)
+	b.ResetTimer()
+	for range b.N {
+		g.forwardPropagationGraph(input...)
+	}
+}
+
+func BenchmarkForwardPropagation100(b *testing.B)  { benchmarkForwardPropagation(b, 100) }
+func BenchmarkForwardPropagation300(b *testing.B)  { benchmarkForwardPropagation(b, 300) }
+func BenchmarkForwardPropagation1000(b *testing.B) { benchmarkForwardPropagation(b, 1000) }
+
+func BenchmarkForwardPropagationGraph100(b *testing.B)  { benchmarkForwardPropagationGraph(b, 100) }
+func BenchmarkForwardPropagationGraph300(b *testing.B)  { benchmarkForwardPropagationGraph(b, 300) }
+func BenchmarkForwardPropagationGraph1000(b *testing.B) { benchmarkForwardPropagationGraph(b, 1000) }
@@ -2,9 +2,9 @@ module github.com/matwate/sometinyai
 
 go 1.23.4
 
-require github.com/dominikbraun/graph v0.23.0
-
 require (
-	github.com/golang/protobuf v1.5.4 // indirect
-	google.golang.org/protobuf v1.36.2 // indirect
+	github.com/dominikbraun/graph v0.23.0
+	google.golang.org/protobuf v1.36.2
 )
+
+require github.com/golang/protobuf v1.5.4 // indirect
@@ -0,0 +1,135 @@
+package sometinyai
+
+import (
+	"sync"
+
+	pb "github.com/matwate/sometinyai/protos"
+)
+
+// InnovationTracker hands out the historical markings of a population: an
+// innovation number for every connection and an id for every hidden node.
+// Genomes sharing a tracker can have their genes aligned, and identical
+// structural mutations made in the same generation get the same markings.
+type InnovationTracker struct {
+	mu             sync.Mutex
+	inputs         int
+	outputs        int
+	nextInnovation int
+	nextNode       int
+	part, parts    int            // Markings handed out are part modulo parts
+	connections    map[[2]int]int // (from, to) -> innovation, for this generation
+	splits         map[int]int    // Split connection innovation -> node, for this generation
+}
+
+func NewInnovationTracker(inputs, outputs int) *InnovationTracker {
+	return &InnovationTracker{
+		inputs:         inputs,
+		outputs:        outputs,
+		nextInnovation: inputs * outputs, // The initial connections take the first ones
+		nextNode:       inputs + outputs,
+		parts:          1,
+		connections:    map[[2]int]int{},
+		splits:         map[int]int{},
+	}
+}
+
+// InnovationTrackerFromProto restores a tracker saved with ToProto.
+func InnovationTrackerFromProto(m *pb.InnovationTracker) *InnovationTracker {
+	t := NewInnovationTracker(int(m.GetInputs()), int(m.GetOutputs()))
+	t.nextInnovation = int(m.GetNextInnovation())
+	t.nextNode = int(m.GetNextNode())
+	t.part, t.parts = int(m.GetPart()), max(int(m.GetParts()), 1)
+	return t
+}
+
+// ToProto converts the tracker to a message. The markings of the current
+// generation are not kept, the restored tracker starts a new one.
+func (t *InnovationTracker) ToProto() *pb.InnovationTracker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return &pb.InnovationTracker{
+		Inputs:         int32(t.inputs),
+		Outputs:        int32(t.outputs),
+		NextInnovation: int32(t.nextInnovation),
+		NextNode:       int32(t.nextNode),
+		Part:           int32(t.part),
+		Parts:          int32(t.parts),
+	}
+}
+
+// Partition makes the tracker only hand out the markings equal to part
+// modulo parts, so that trackers given different parts never hand out the
+// same ones. Populations with a tracker each can then exchange genomes.
+func (t *InnovationTracker) Partition(part, parts int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.part, t.parts = part, parts
+	t.nextInnovation = t.align(t.nextInnovation)
+	t.nextNode = t.align(t.nextNode)
+}
+
+// align returns the first marking of the tracker's part from v on.
+func (t *InnovationTracker) align(v int) int {
+	return v + ((t.part-v)%t.parts+t.parts)%t.parts
+}
+
+// NextGeneration forgets the mutations of the current generation, so that
+// the same structural change made later gets a new marking.
+func (t *InnovationTracker) NextGeneration() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	clear(t.connections)
+	clear(t.splits)
+}
+
+// connection returns the innovation number of the connection from -> to.
+func (t *InnovationTracker) connection(from, to int) int {
+	// Connections between inputs and outputs are the ones NewGenome creates,
+	// they are numbered the same way in every genome
+	if from < t.inputs && to >= t.inputs && to < t.inputs+t.outputs {
+		return from*t.outputs + to - t.inputs
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := [2]int{from, to}
+	if innovation, ok := t.connections[key]; ok {
+		return innovation
+	}
+	innovation := t.nextInnovation
+	t.nextInnovation += t.parts
+	t.connections[key] = innovation
+	return innovation
+}
+
+// split returns the id of the node that splits the connection with the
+// given innovation number.
+func (t *InnovationTracker) split(innovation int) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if node, ok := t.splits[innovation]; ok {
+		return node
+	}
+	node := t.nextNode
+	t.nextNode += t.parts
+	t.splits[innovation] = node
+	return node
+}
+
+// node returns a fresh node id that no split will ever reuse.
+func (t *InnovationTracker) node() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node := t.nextNode
+	t.nextNode += t.parts
+	return node
+}
+
+// observe makes sure the tracker never hands out markings that are already
+// in use by a genome it did not create, like a loaded one.
+func (t *InnovationTracker) observe(innovation, node int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.nextInnovation = max(t.nextInnovation, t.align(innovation+1))
+	t.nextNode = max(t.nextNode, t.align(node+1))
+}
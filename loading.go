@@ -1,8 +1,13 @@
 package sometinyai
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"reflect"
+	"slices"
 
 	"github.com/dominikbraun/graph"
 	"google.golang.org/protobuf/proto"
@@ -11,41 +16,75 @@ import (
 	pb "github.com/matwate/sometinyai/protos"
 )
 
-func (g *Genome) Save(filename string, act activation.ActivationFunction) error {
+// Save writes the genome to filename, along with the activation of every node
+// that has one of its own. act is only needed to name the genome's default
+// activation function when it has not been registered in the activation
+// package, otherwise saving such a genome fails, and has to stand for that
+// function.
+func (g *Genome) Save(filename string, act ...activation.ActivationFunction) error {
+	genome, err := g.ToProto(act...)
+	if err != nil {
+		return err
+	}
+	out, err := proto.Marshal(genome)
+	if err != nil {
+		return err
+	}
+	Logger().Debug("saving genome", "file", filename, "bytes", len(out))
+	if err := os.WriteFile(filename, out, 0644); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ToProto converts the genome to the message Save writes, see Save for act.
+func (g *Genome) ToProto(act ...activation.ActivationFunction) (*pb.Genome, error) {
 	genome := &pb.Genome{}
 	genome.Inputs = int32(g.input)
 	genome.Outputs = int32(g.output)
 	genome.Neurons = int32(g.input + g.output + g.hidden)
+	genome.Recurrent = g.recurrent
+	genome.FixedOutputs = g.fixedOutputs
 	connections := []*pb.Connection{}
 	adj, _ := g.graph.AdjacencyMap()
 	for source, targets := range adj {
 		for target, edge := range targets {
 			data := edge.Properties.Data.(*EdgeConnectionData)
 			conn := &pb.Connection{
-				In:     int32(source),
-				Out:    int32(target),
-				Weight: float64(data.weight),
-				Bias:   float64(data.bias),
+				In:         int32(source),
+				Out:        int32(target),
+				Weight:     float64(data.weight),
+				Bias:       float64(data.bias),
+				Innovation: int32(data.innovation),
 			}
 			connections = append(connections, conn)
 		}
 	}
+	slices.SortFunc(connections, func(a, b *pb.Connection) int {
+		return cmp.Compare(a.Innovation, b.Innovation)
+	})
 	genome.Connections = connections
-	genome.Activation = map[activation.ActivationFunction]string{
-		activation.Sigmoid_T:   "Sigmoid",
-		activation.LeakyRelu_T: "LeakyRelu",
-		activation.Relu_T:      "Relu",
-		activation.Tanh_T:      "Tanh",
-	}[act]
-	out, err := proto.Marshal(genome)
-	fmt.Printf("Size of the genome: %d Bytes\n", len(out))
-	if err != nil {
-		return err
+
+	var ok bool
+	if len(act) > 0 {
+		genome.Activation, ok = activation.Name(act[0])
+		if ok && reflect.ValueOf(act[0].Func()).Pointer() != reflect.ValueOf(g.activationFunction).Pointer() {
+			return nil, fmt.Errorf("activation %s is not the activation function of the genome", genome.Activation)
+		}
+	} else {
+		genome.Activation, ok = activation.Name(g.activationFunction)
 	}
-	if err := os.WriteFile(filename, out, 0644); err != nil {
-		return err
+	if !ok {
+		return nil, errors.New("the activation function of the genome is not registered")
 	}
-	return nil
+	for _, node := range slices.Sorted(maps.Keys(adj)) {
+		n := &pb.Node{Id: int32(node)}
+		if a, ok := g.activations[node]; ok {
+			n.Activation, _ = activation.Name(a)
+		}
+		genome.Nodes = append(genome.Nodes, n)
+	}
+	return genome, nil
 }
 
 func LoadGenome(filename string) (*Genome, error) {
@@ -58,44 +97,84 @@ func LoadGenome(filename string) (*Genome, error) {
 	if err := proto.Unmarshal(in, genome); err != nil {
 		return nil, err
 	}
-	return toGenome(genome), nil
+	return GenomeFromProto(genome)
 }
 
-func toGenome(genome *pb.Genome) *Genome {
-	act := map[string]func(float64) float64{
-		"Sigmoid":   activation.Sigmoid,
-		"LeakyRelu": activation.LeakyRelu,
-		"Relu":      activation.Relu,
-		"Tanh":      activation.Tanh,
+// GenomeFromProto builds a genome from the message written by Save. The
+// options are applied before the genes are read, what the message holds
+// takes precedence, and a tracker given with WithInnovationTracker is kept
+// from handing out the markings the genome already uses.
+func GenomeFromProto(genome *pb.Genome, opts ...GenomeOption) (*Genome, error) {
+	act, ok := activation.Lookup(genome.GetActivation())
+	if !ok {
+		return nil, fmt.Errorf("unknown activation %q", genome.GetActivation())
 	}
 
-	gr := graph.New(graph.IntHash, graph.Directed(), graph.Acyclic())
-
-	for i := range genome.GetInputs() {
-		gr.AddVertex(int(i))
+	inputs, outputs := int(genome.GetInputs()), int(genome.GetOutputs())
+	g := &Genome{
+		input:       inputs,
+		output:      outputs,
+		activations: map[int]activation.ActivationFunction{},
 	}
-	for i := range genome.GetOutputs() {
-		gr.AddVertex(int(i + genome.GetInputs()))
+	for _, opt := range opts {
+		opt(g)
 	}
+	if g.innovations == nil {
+		g.innovations = NewInnovationTracker(inputs, outputs)
+	}
+	tracker := g.innovations
+	gr := newGraph(genome.GetRecurrent())
 
-	for i := range genome.GetNeurons() {
-		gr.AddVertex(int(i + genome.GetInputs() + genome.GetOutputs()))
+	hidden := 0
+	if len(genome.GetNodes()) > 0 {
+		for _, node := range genome.GetNodes() {
+			id := int(node.GetId())
+			gr.AddVertex(id)
+			if id >= inputs+outputs {
+				hidden++
+			}
+			tracker.observe(0, id)
+		}
+	} else {
+		// Files written before nodes were stored only have the total count,
+		// and number the hidden nodes right after the outputs
+		hidden = int(genome.GetNeurons()) - inputs - outputs
+		for i := range inputs + outputs + hidden {
+			gr.AddVertex(i)
+		}
+		tracker.observe(0, inputs+outputs+hidden-1)
 	}
 
 	for _, conn := range genome.GetConnections() {
-		gr.AddEdge(int(conn.GetIn()), int(conn.GetOut()), graph.EdgeData(&EdgeConnectionData{
-			weight: conn.GetWeight(),
-			bias:   conn.GetBias(),
+		from, to := int(conn.GetIn()), int(conn.GetOut())
+		innovation := int(conn.GetInnovation())
+		if len(genome.GetNodes()) == 0 {
+			// Nor did they have innovation numbers
+			innovation = tracker.connection(from, to)
+		}
+		tracker.observe(innovation, 0)
+		gr.AddEdge(from, to, graph.EdgeData(&EdgeConnectionData{
+			weight:     conn.GetWeight(),
+			bias:       conn.GetBias(),
+			innovation: innovation,
 		}))
 	}
 
-	g := &Genome{
-		graph:              gr,
-		input:              int(genome.GetInputs()),
-		output:             int(genome.GetOutputs()),
-		hidden:             int(genome.GetNeurons()),
-		activationFunction: act[genome.GetActivation()],
-		order:              nil,
-	}
-	return g
+	for _, node := range genome.GetNodes() {
+		if node.GetActivation() == "" {
+			continue
+		}
+		a, ok := activation.Lookup(node.GetActivation())
+		if !ok {
+			return nil, fmt.Errorf("node %d: unknown activation %q", node.GetId(), node.GetActivation())
+		}
+		g.activations[int(node.GetId())] = a
+	}
+
+	g.graph = gr
+	g.hidden = hidden
+	g.activationFunction = act.Func()
+	g.fixedOutputs = genome.GetFixedOutputs()
+	g.recurrent = genome.GetRecurrent()
+	return g, nil
 }
@@ -0,0 +1,75 @@
+package sometinyai
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/matwate/sometinyai/activation"
+	pb "github.com/matwate/sometinyai/protos"
+)
+
+func TestLoadGenomeUnknownActivation(t *testing.T) {
+	tests := []struct {
+		name   string
+		genome *pb.Genome
+		want   string
+	}{
+		{
+			name:   "default",
+			genome: &pb.Genome{Inputs: 1, Outputs: 1, Activation: "Gauss"},
+			want:   `unknown activation "Gauss"`,
+		},
+		{
+			name: "node",
+			genome: &pb.Genome{Inputs: 1, Outputs: 1, Activation: "Tanh", Nodes: []*pb.Node{
+				{Id: 0},
+				{Id: 1, Activation: "Gauss"},
+			}},
+			want: `node 1: unknown activation "Gauss"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := proto.Marshal(tt.genome)
+			if err != nil {
+				t.Fatal(err)
+			}
+			filename := filepath.Join(t.TempDir(), "test.genome")
+			if err := os.WriteFile(filename, out, 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadGenome(filename); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("got error %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestToProtoActivation(t *testing.T) {
+	scaled := func(k float64) func(float64) float64 {
+		return func(x float64) float64 { return k * x }
+	}
+	double := scaled(2)
+	g := NewGenome(1, 1, double)
+	if _, err := g.ToProto(); err == nil {
+		t.Error("saved an unregistered activation")
+	}
+	if _, err := g.ToProto(activation.Sigmoid_T); err == nil {
+		t.Error("saved the activation under the name of another function")
+	}
+
+	a := activation.Register("TestScaled", double)
+	if _, err := g.ToProto(a); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("registered a second closure of the same literal")
+		}
+	}()
+	activation.Register("TestScaledHalf", scaled(0.5))
+}
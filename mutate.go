@@ -1,108 +1,355 @@
 package sometinyai
 
 import (
+	"maps"
 	"math/rand/v2"
+	"slices"
 
 	"github.com/dominikbraun/graph"
+
+	"github.com/matwate/sometinyai/activation"
 )
 
-func (g *Genome) Mutate(count int) {
-	// 0.1 chance to split a connection
-	// 0.2 chance to add a connection
-	// 0.5 chance to change a weight
-	// 0.2 chance to change a bias
-	// They all can happen at the same time
+// MutationConfig sets how Mutate explores. Each operator has its own chance
+// to run on every round, and they all can happen at the same time.
+type MutationConfig struct {
+	SplitConnection  float64
+	AddConnection    float64
+	ChangeWeight     float64
+	ChangeBias       float64
+	ChangeActivation float64
+	RemoveConnection float64
+	RemoveNode       float64
+
+	AddConnectionAttempts int // Pairs of nodes AddConnection tries before giving up
+
+	Sigma   float64 // Standard deviation of the weight and bias perturbations
+	Replace float64 // Chance a weight or bias is drawn anew instead of perturbed
+
+	// Weights and biases changed by a mutation are clamped to this range,
+	// unless both ends are zero
+	MinValue, MaxValue float64
+}
+
+// DefaultMutationConfig is the configuration Mutate uses when given none.
+func DefaultMutationConfig() MutationConfig {
+	return MutationConfig{
+		SplitConnection:  0.1,
+		AddConnection:    0.2,
+		ChangeWeight:     0.5,
+		ChangeBias:       0.2,
+		ChangeActivation: 0.1,
+		RemoveConnection: 0.05,
+		RemoveNode:       0.02,
+		Sigma:            1,
+
+		AddConnectionAttempts: 20,
+	}
+}
+
+// change perturbs or replaces a weight or bias.
+func (c MutationConfig) change(r *rand.Rand, v float64) float64 {
+	if r.Float64() < c.Replace {
+		v = r.NormFloat64()
+	} else {
+		v += r.NormFloat64() * c.Sigma
+	}
+	if c.MinValue != 0 || c.MaxValue != 0 {
+		v = min(max(v, c.MinValue), c.MaxValue)
+	}
+	return v
+}
+
+// Mutate runs count rounds of mutations, with the default configuration
+// unless one is given.
+func (g *Genome) Mutate(count int, config ...MutationConfig) {
+	c := DefaultMutationConfig()
+	if len(config) > 0 {
+		c = config[0]
+	}
+	r := g.Rand()
 	for i := 0; i < count; i++ {
-		n := rand.Float64()
-		if n < 0.1 {
+		if r.Float64() < c.SplitConnection {
 			g.SplitConnection()
 		}
-		if n < 0.2 {
-			g.AddConnection()
+		if r.Float64() < c.AddConnection {
+			g.addConnection(c)
+		}
+		if r.Float64() < c.ChangeWeight {
+			g.changeWeight(c)
+		}
+		if r.Float64() < c.ChangeBias {
+			g.changeBias(c)
 		}
-		if n < 0.5 {
-			g.ChangeWeight()
+		if r.Float64() < c.ChangeActivation {
+			g.ChangeActivation()
 		}
-		if n < 0.2 {
-			g.ChangeBias()
+		if r.Float64() < c.RemoveConnection {
+			g.RemoveConnection()
+		}
+		if r.Float64() < c.RemoveNode {
+			g.RemoveNode()
 		}
 	}
-	g.order = nil // Cache invalidation???
 }
 
 func (g *Genome) SplitConnection() {
 	edges, _ := g.graph.AdjacencyMap()
 	// Find a non output node:
-	n := rand.IntN(g.input + g.hidden)
-	if n >= g.input {
-		n += g.output
-	}
-	node := edges[n]
+	node := edges[g.randomSource(edges)]
 	if len(node) == 0 {
 		return
 	}
-	edge := RandomValueOfMap(node)
+	edge := node[randomKey(g.Rand(), node)]
 	from, to := edge.Source, edge.Target
-	weight, bias := edge.Properties.Data.(*EdgeConnectionData).weight, edge.Properties.Data.(*EdgeConnectionData).bias
-	g.graph.AddVertex(g.input + g.output + g.hidden)
+	data := edge.Properties.Data.(*EdgeConnectionData)
+
+	// Every genome splitting this connection in the same generation gets the
+	// same node, unless this genome already has it
+	split := g.innovations.split(data.innovation)
+	if _, err := g.graph.Vertex(split); err == nil {
+		split = g.innovations.node()
+	}
+	g.graph.AddVertex(split)
 	g.hidden++
-	g.graph.AddEdge(from, g.hidden+g.input+g.output-1, graph.EdgeData(NewEdgeConnectionData(1, 0)))
-	g.graph.AddEdge(
-		g.hidden+g.input+g.output-1,
-		to,
-		graph.EdgeData(NewEdgeConnectionData(weight, bias)),
-	)
+	g.graph.AddEdge(from, split, graph.EdgeData(&EdgeConnectionData{
+		weight:     1,
+		bias:       0,
+		innovation: g.innovations.connection(from, split),
+	}))
+	g.graph.AddEdge(split, to, graph.EdgeData(&EdgeConnectionData{
+		weight:     data.weight,
+		bias:       data.bias,
+		innovation: g.innovations.connection(split, to),
+	}))
 	g.graph.RemoveEdge(from, to)
+	g.invalidate()
 }
 
-func (g *Genome) AddConnection() {
+// AddConnection connects a random pair of nodes that are not connected yet,
+// as configured by DefaultMutationConfig, and reports whether it did.
+func (g *Genome) AddConnection() bool {
+	return g.addConnection(DefaultMutationConfig())
+}
+
+// addConnection draws up to c.AddConnectionAttempts pairs of a source, an
+// input or hidden node, and a target, a hidden or output node, and connects
+// the first one that is not connected yet. In a feed forward genome pairs
+// closing a cycle are rejected too, a recurrent one takes them, self loops
+// included.
+func (g *Genome) addConnection(c MutationConfig) bool {
 	edges, _ := g.graph.AdjacencyMap()
-	// Find a non output node:
-	n := rand.IntN(g.input + g.hidden)
-	if n >= g.input {
-		n += g.output
-	}
-	node := edges[n]
-	if len(node) == 0 {
-		return
+	targets := make([]int, 0, g.output+g.hidden)
+	for node := range edges {
+		if !g.isInput(node) {
+			targets = append(targets, node)
+		}
 	}
-	edge := RandomValueOfMap(node)
-	from, to := edge.Source, edge.Target
-	err := g.graph.AddEdge(from, to, graph.EdgeData(NewEdgeConnectionData(-1, -1)))
-	if err != nil {
-		return // we assume that the map is full
+	slices.Sort(targets)
+
+	for range max(c.AddConnectionAttempts, 1) {
+		from := g.randomSource(edges)
+		to := targets[g.Rand().IntN(len(targets))]
+		if _, ok := edges[from][to]; ok {
+			continue
+		}
+		if !g.recurrent {
+			if cycle, _ := graph.CreatesCycle(g.graph, from, to); cycle || from == to {
+				continue
+			}
+		}
+		data := newEdgeConnectionData(g.Rand(), -1, -1)
+		data.innovation = g.innovations.connection(from, to)
+		if err := g.graph.AddEdge(from, to, graph.EdgeData(data)); err != nil {
+			continue
+		}
+		g.invalidate()
+		return true
 	}
+	return false
 }
 
+// ChangeWeight changes the weight of a random connection, as configured by
+// DefaultMutationConfig.
 func (g *Genome) ChangeWeight() {
+	g.changeWeight(DefaultMutationConfig())
+}
+
+func (g *Genome) changeWeight(c MutationConfig) {
 	edges, _ := g.graph.AdjacencyMap()
 	// Find a non output node:
-	n := rand.IntN(g.input + g.hidden)
-	if n >= g.input {
-		n += g.output
-	}
-	node := edges[n]
+	node := edges[g.randomSource(edges)]
 	if len(node) == 0 {
 		return
 	}
-	edge := RandomValueOfMap(node)
-	edge.Properties.Data.(*EdgeConnectionData).weight = edge.Properties.Data.(*EdgeConnectionData).weight + rand.NormFloat64()
+	edge := node[randomKey(g.Rand(), node)]
+	data := edge.Properties.Data.(*EdgeConnectionData)
+	data.weight = c.change(g.Rand(), data.weight)
+	g.invalidate()
 }
 
+// ChangeBias changes the bias of a random connection, as configured by
+// DefaultMutationConfig.
 func (g *Genome) ChangeBias() {
+	g.changeBias(DefaultMutationConfig())
+}
+
+func (g *Genome) changeBias(c MutationConfig) {
 	edges, _ := g.graph.AdjacencyMap()
 	// Find a non output node:
-	n := rand.IntN(g.input + g.hidden)
-	if n >= g.input {
-		n += g.output
+	node := edges[g.randomSource(edges)]
+	if len(node) == 0 {
+		return
 	}
-	node := edges[n]
+	edge := node[randomKey(g.Rand(), node)]
+	data := edge.Properties.Data.(*EdgeConnectionData)
+	data.bias = c.change(g.Rand(), data.bias)
+	g.invalidate()
+}
+
+// ChangeActivation gives a random hidden node, or output node unless they are
+// fixed, a different activation function.
+func (g *Genome) ChangeActivation() {
+	edges, _ := g.graph.AdjacencyMap()
+	nodes := make([]int, 0, g.output+g.hidden)
+	for node := range edges {
+		if node >= g.input+g.output || (node >= g.input && !g.fixedOutputs) {
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) == 0 {
+		return
+	}
+	slices.Sort(nodes)
+	node := nodes[g.Rand().IntN(len(nodes))]
+
+	choices := activation.All()
+	if current, ok := g.Activation(node); ok {
+		choices = slices.DeleteFunc(choices, func(a activation.ActivationFunction) bool { return a == current })
+	}
+	g.activations[node] = choices[g.Rand().IntN(len(choices))]
+	g.invalidate()
+}
+
+// RemoveConnection removes a random connection, unless it is the last one
+// feeding an output node, and then the hidden nodes it leaves dangling.
+func (g *Genome) RemoveConnection() {
+	edges, _ := g.graph.AdjacencyMap()
+	node := edges[g.randomSource(edges)]
 	if len(node) == 0 {
 		return
 	}
-	edge := RandomValueOfMap(node)
+	edge := node[randomKey(g.Rand(), node)]
+	if g.isOutput(edge.Target) && edge.Source != edge.Target {
+		predecessors, _ := g.graph.PredecessorMap()
+		if links(predecessors[edge.Target], edge.Target) == 1 {
+			return
+		}
+	}
+	g.graph.RemoveEdge(edge.Source, edge.Target)
+	g.prune()
+	g.invalidate()
+}
+
+// RemoveNode removes a random hidden node along with its connections, and
+// then the hidden nodes it leaves dangling. Nodes that are the only input of
+// an output node are never removed.
+func (g *Genome) RemoveNode() {
+	predecessors, _ := g.graph.PredecessorMap()
+	edges, _ := g.graph.AdjacencyMap()
+	var candidates []int
+	for node := range edges {
+		if g.isInput(node) || g.isOutput(node) || g.feedsOutput(node, edges, predecessors) {
+			continue
+		}
+		candidates = append(candidates, node)
+	}
+	if len(candidates) == 0 {
+		return
+	}
+	slices.Sort(candidates)
+	g.removeNode(candidates[g.Rand().IntN(len(candidates))], edges, predecessors)
+	g.prune()
+	g.invalidate()
+}
+
+// prune removes the hidden nodes left without inputs or without outputs, a
+// self-loop counting as neither, unless they are the only input of an output
+// node.
+func (g *Genome) prune() {
+	for {
+		predecessors, _ := g.graph.PredecessorMap()
+		edges, _ := g.graph.AdjacencyMap()
+		dangling := -1
+		for _, node := range slices.Sorted(maps.Keys(edges)) {
+			if g.isInput(node) || g.isOutput(node) || g.feedsOutput(node, edges, predecessors) {
+				continue
+			}
+			if links(predecessors[node], node) == 0 || links(edges[node], node) == 0 {
+				dangling = node
+				break
+			}
+		}
+		if dangling < 0 {
+			return
+		}
+		g.removeNode(dangling, edges, predecessors)
+	}
+}
 
-	edge.Properties.Data.(*EdgeConnectionData).bias = edge.Properties.Data.(*EdgeConnectionData).bias + rand.NormFloat64()
+// feedsOutput reports whether node is the only input of an output node.
+func (g *Genome) feedsOutput(node int, edges, predecessors map[int]map[int]graph.Edge[int]) bool {
+	for target := range edges[node] {
+		if g.isOutput(target) && target != node && links(predecessors[target], target) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Genome) removeNode(node int, edges, predecessors map[int]map[int]graph.Edge[int]) {
+	for target := range edges[node] {
+		g.graph.RemoveEdge(node, target)
+	}
+	for source := range predecessors[node] {
+		if source != node {
+			g.graph.RemoveEdge(source, node)
+		}
+	}
+	g.graph.RemoveVertex(node)
+	delete(g.activations, node)
+	g.hidden--
+}
+
+// links counts the connections of node to or from edges, leaving out its
+// self-loop.
+func links(edges map[int]graph.Edge[int], node int) int {
+	n := len(edges)
+	if _, ok := edges[node]; ok {
+		n--
+	}
+	return n
+}
+
+func (g *Genome) isInput(node int) bool {
+	return node < g.input
+}
+
+func (g *Genome) isOutput(node int) bool {
+	return node >= g.input && node < g.input+g.output
+}
+
+// randomSource returns a random node a connection can start from: an input
+// or a hidden node, or an output too in a recurrent genome.
+func (g *Genome) randomSource(edges map[int]map[int]graph.Edge[int]) int {
+	sources := make([]int, 0, len(edges))
+	for node := range edges {
+		if g.recurrent || node < g.input || node >= g.input+g.output {
+			sources = append(sources, node)
+		}
+	}
+	slices.Sort(sources)
+	return sources[g.Rand().IntN(len(sources))]
 }
 
 func RandomValueOfMap[T comparable, Y any](m map[T]Y) Y {
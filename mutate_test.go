@@ -0,0 +1,125 @@
+package sometinyai
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/matwate/sometinyai/activation"
+)
+
+// testGenome builds a genome with one input, node 0, one output, node 1,
+// and the given hidden nodes, numbered from 2, and connections.
+func testGenome(recurrent bool, hidden int, edges ...[2]int) *Genome {
+	var opts []GenomeOption
+	if recurrent {
+		opts = append(opts, Recurrent())
+	}
+	g := NewGenome(1, 1, activation.Sigmoid, opts...)
+	g.graph.RemoveEdge(0, 1)
+	for range hidden {
+		g.AddNode()
+	}
+	for _, e := range edges {
+		g.AddEdge(e[0], e[1], NewEdgeConnectionData(1, 0))
+	}
+	return g
+}
+
+func hiddenNodes(g *Genome) []int {
+	edges, _ := g.graph.AdjacencyMap()
+	var nodes []int
+	for node := range edges {
+		if !g.isInput(node) && !g.isOutput(node) {
+			nodes = append(nodes, node)
+		}
+	}
+	slices.Sort(nodes)
+	return nodes
+}
+
+func TestPrune(t *testing.T) {
+	tests := []struct {
+		name      string
+		recurrent bool
+		hidden    int
+		edges     [][2]int
+		want      []int
+	}{
+		{"connected", false, 1, [][2]int{{0, 1}, {0, 2}, {2, 1}}, []int{2}},
+		{"no inputs", false, 1, [][2]int{{0, 1}, {2, 1}}, nil},
+		{"no outputs", false, 1, [][2]int{{0, 1}, {0, 2}}, nil},
+		{"self-loop", true, 1, [][2]int{{0, 1}, {0, 2}, {2, 2}}, nil},
+		{"chain", false, 2, [][2]int{{0, 1}, {0, 2}, {2, 3}}, nil},
+		{"only input of the output", false, 1, [][2]int{{2, 1}}, []int{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := testGenome(tt.recurrent, tt.hidden, tt.edges...)
+			g.prune()
+			if got := hiddenNodes(g); !slices.Equal(got, tt.want) {
+				t.Errorf("hidden nodes %v, want %v", got, tt.want)
+			}
+			if g.hidden != len(tt.want) {
+				t.Errorf("counted %d hidden nodes, want %d", g.hidden, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestRemoveConnectionKeepsOutputInput(t *testing.T) {
+	// The output's self-loop is not an input of its own
+	g := testGenome(true, 0, [2]int{0, 1}, [2]int{1, 1})
+	g.SetRand(rand.New(rand.NewPCG(1, 2)))
+	for range 20 {
+		g.RemoveConnection()
+	}
+	if _, err := g.graph.Edge(0, 1); err != nil {
+		t.Fatal("removed the only input of the output")
+	}
+}
+
+func TestRemoveNodeKeepsOutputInput(t *testing.T) {
+	g := testGenome(true, 1, [2]int{0, 2}, [2]int{2, 1}, [2]int{1, 1})
+	g.SetRand(rand.New(rand.NewPCG(1, 2)))
+	g.RemoveNode()
+	if got := hiddenNodes(g); !slices.Equal(got, []int{2}) {
+		t.Fatalf("hidden nodes %v, want [2]", got)
+	}
+}
+
+func TestAddConnection(t *testing.T) {
+	tests := []struct {
+		name      string
+		recurrent bool
+		edges     [][2]int
+		want      bool
+		added     [2]int
+	}{
+		// Three of the four pairs are connected or a self-loop
+		{"one pair left", false, [][2]int{{0, 1}, {0, 2}}, true, [2]int{2, 1}},
+		{"connected", false, [][2]int{{0, 1}, {0, 2}, {2, 1}}, false, [2]int{}},
+		{"self-loop", true, [][2]int{{0, 1}, {0, 2}, {2, 1}, {1, 1}, {1, 2}}, true, [2]int{2, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := testGenome(tt.recurrent, 1, tt.edges...)
+			g.SetRand(rand.New(rand.NewPCG(1, 2)))
+			c := DefaultMutationConfig()
+			c.AddConnectionAttempts = 100
+			if got := g.addConnection(c); got != tt.want {
+				t.Fatalf("reported %v, want %v", got, tt.want)
+			}
+			want := len(tt.edges)
+			if tt.want {
+				if _, err := g.graph.Edge(tt.added[0], tt.added[1]); err != nil {
+					t.Errorf("did not connect %v", tt.added)
+				}
+				want++
+			}
+			if _, connections := g.Size(); connections != want {
+				t.Errorf("%d connections, want %d", connections, want)
+			}
+		})
+	}
+}
@@ -28,6 +28,9 @@ type Genome struct {
 	Neurons       int32                  `protobuf:"varint,3,opt,name=neurons,proto3" json:"neurons,omitempty"`
 	Connections   []*Connection          `protobuf:"bytes,4,rep,name=connections,proto3" json:"connections,omitempty"`
 	Activation    string                 `protobuf:"bytes,5,opt,name=activation,proto3" json:"activation,omitempty"`
+	Nodes         []*Node                `protobuf:"bytes,6,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Recurrent     bool                   `protobuf:"varint,7,opt,name=recurrent,proto3" json:"recurrent,omitempty"`
+	FixedOutputs  bool                   `protobuf:"varint,8,opt,name=fixed_outputs,json=fixedOutputs,proto3" json:"fixed_outputs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -97,12 +100,34 @@ func (x *Genome) GetActivation() string {
 	return ""
 }
 
+func (x *Genome) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *Genome) GetRecurrent() bool {
+	if x != nil {
+		return x.Recurrent
+	}
+	return false
+}
+
+func (x *Genome) GetFixedOutputs() bool {
+	if x != nil {
+		return x.FixedOutputs
+	}
+	return false
+}
+
 type Connection struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	In            int32                  `protobuf:"varint,1,opt,name=in,proto3" json:"in,omitempty"`
 	Out           int32                  `protobuf:"varint,2,opt,name=out,proto3" json:"out,omitempty"`
 	Weight        float64                `protobuf:"fixed64,3,opt,name=weight,proto3" json:"weight,omitempty"`
 	Bias          float64                `protobuf:"fixed64,4,opt,name=bias,proto3" json:"bias,omitempty"`
+	Innovation    int32                  `protobuf:"varint,5,opt,name=innovation,proto3" json:"innovation,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -165,12 +190,1535 @@ func (x *Connection) GetBias() float64 {
 	return 0
 }
 
+func (x *Connection) GetInnovation() int32 {
+	if x != nil {
+		return x.Innovation
+	}
+	return 0
+}
+
+type Node struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Activation    string                 `protobuf:"bytes,2,opt,name=activation,proto3" json:"activation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Node) Reset() {
+	*x = Node{}
+	mi := &file_protos_genome_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Node) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Node) ProtoMessage() {}
+
+func (x *Node) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_genome_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Node.ProtoReflect.Descriptor instead.
+func (*Node) Descriptor() ([]byte, []int) {
+	return file_protos_genome_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Node) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Node) GetActivation() string {
+	if x != nil {
+		return x.Activation
+	}
+	return ""
+}
+
+type InnovationTracker struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Inputs         int32                  `protobuf:"varint,1,opt,name=inputs,proto3" json:"inputs,omitempty"`
+	Outputs        int32                  `protobuf:"varint,2,opt,name=outputs,proto3" json:"outputs,omitempty"`
+	NextInnovation int32                  `protobuf:"varint,3,opt,name=next_innovation,json=nextInnovation,proto3" json:"next_innovation,omitempty"`
+	NextNode       int32                  `protobuf:"varint,4,opt,name=next_node,json=nextNode,proto3" json:"next_node,omitempty"`
+	Part           int32                  `protobuf:"varint,5,opt,name=part,proto3" json:"part,omitempty"`
+	Parts          int32                  `protobuf:"varint,6,opt,name=parts,proto3" json:"parts,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *InnovationTracker) Reset() {
+	*x = InnovationTracker{}
+	mi := &file_protos_genome_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InnovationTracker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InnovationTracker) ProtoMessage() {}
+
+func (x *InnovationTracker) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_genome_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InnovationTracker.ProtoReflect.Descriptor instead.
+func (*InnovationTracker) Descriptor() ([]byte, []int) {
+	return file_protos_genome_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *InnovationTracker) GetInputs() int32 {
+	if x != nil {
+		return x.Inputs
+	}
+	return 0
+}
+
+func (x *InnovationTracker) GetOutputs() int32 {
+	if x != nil {
+		return x.Outputs
+	}
+	return 0
+}
+
+func (x *InnovationTracker) GetNextInnovation() int32 {
+	if x != nil {
+		return x.NextInnovation
+	}
+	return 0
+}
+
+func (x *InnovationTracker) GetNextNode() int32 {
+	if x != nil {
+		return x.NextNode
+	}
+	return 0
+}
+
+func (x *InnovationTracker) GetPart() int32 {
+	if x != nil {
+		return x.Part
+	}
+	return 0
+}
+
+func (x *InnovationTracker) GetParts() int32 {
+	if x != nil {
+		return x.Parts
+	}
+	return 0
+}
+
+type Checkpoint struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Generation       int32                  `protobuf:"varint,1,opt,name=generation,proto3" json:"generation,omitempty"`
+	Population       []*Agent               `protobuf:"bytes,2,rep,name=population,proto3" json:"population,omitempty"`
+	Innovations      *InnovationTracker     `protobuf:"bytes,3,opt,name=innovations,proto3" json:"innovations,omitempty"`
+	Species          []*Species             `protobuf:"bytes,4,rep,name=species,proto3" json:"species,omitempty"`
+	NextSpecies      int32                  `protobuf:"varint,5,opt,name=next_species,json=nextSpecies,proto3" json:"next_species,omitempty"`
+	BestScore        float64                `protobuf:"fixed64,6,opt,name=best_score,json=bestScore,proto3" json:"best_score,omitempty"`
+	Options          *SimulationOptions     `protobuf:"bytes,7,opt,name=options,proto3" json:"options,omitempty"`
+	History          []*GenerationStats     `protobuf:"bytes,8,rep,name=history,proto3" json:"history,omitempty"`
+	Rng              []byte                 `protobuf:"bytes,9,opt,name=rng,proto3" json:"rng,omitempty"`
+	Best             *Agent                 `protobuf:"bytes,10,opt,name=best,proto3" json:"best,omitempty"`
+	Front            []*Agent               `protobuf:"bytes,11,rep,name=front,proto3" json:"front,omitempty"`
+	Archive          []*Behavior            `protobuf:"bytes,12,rep,name=archive,proto3" json:"archive,omitempty"`
+	Stage            int32                  `protobuf:"varint,13,opt,name=stage,proto3" json:"stage,omitempty"`
+	StageGenerations int32                  `protobuf:"varint,14,opt,name=stage_generations,json=stageGenerations,proto3" json:"stage_generations,omitempty"`
+	StageStreak      int32                  `protobuf:"varint,15,opt,name=stage_streak,json=stageStreak,proto3" json:"stage_streak,omitempty"`
+	Plateau          int32                  `protobuf:"varint,16,opt,name=plateau,proto3" json:"plateau,omitempty"`
+	PlateauScore     float64                `protobuf:"fixed64,17,opt,name=plateau_score,json=plateauScore,proto3" json:"plateau_score,omitempty"`
+	Boost            float64                `protobuf:"fixed64,18,opt,name=boost,proto3" json:"boost,omitempty"`
+	HallOfFame       []*Agent               `protobuf:"bytes,19,rep,name=hall_of_fame,json=hallOfFame,proto3" json:"hall_of_fame,omitempty"`
+	Halted           bool                   `protobuf:"varint,20,opt,name=halted,proto3" json:"halted,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Checkpoint) Reset() {
+	*x = Checkpoint{}
+	mi := &file_protos_genome_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Checkpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Checkpoint) ProtoMessage() {}
+
+func (x *Checkpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_genome_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Checkpoint.ProtoReflect.Descriptor instead.
+func (*Checkpoint) Descriptor() ([]byte, []int) {
+	return file_protos_genome_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Checkpoint) GetGeneration() int32 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *Checkpoint) GetPopulation() []*Agent {
+	if x != nil {
+		return x.Population
+	}
+	return nil
+}
+
+func (x *Checkpoint) GetInnovations() *InnovationTracker {
+	if x != nil {
+		return x.Innovations
+	}
+	return nil
+}
+
+func (x *Checkpoint) GetSpecies() []*Species {
+	if x != nil {
+		return x.Species
+	}
+	return nil
+}
+
+func (x *Checkpoint) GetNextSpecies() int32 {
+	if x != nil {
+		return x.NextSpecies
+	}
+	return 0
+}
+
+func (x *Checkpoint) GetBestScore() float64 {
+	if x != nil {
+		return x.BestScore
+	}
+	return 0
+}
+
+func (x *Checkpoint) GetOptions() *SimulationOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Checkpoint) GetHistory() []*GenerationStats {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *Checkpoint) GetRng() []byte {
+	if x != nil {
+		return x.Rng
+	}
+	return nil
+}
+
+func (x *Checkpoint) GetBest() *Agent {
+	if x != nil {
+		return x.Best
+	}
+	return nil
+}
+
+func (x *Checkpoint) GetFront() []*Agent {
+	if x != nil {
+		return x.Front
+	}
+	return nil
+}
+
+func (x *Checkpoint) GetArchive() []*Behavior {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+func (x *Checkpoint) GetStage() int32 {
+	if x != nil {
+		return x.Stage
+	}
+	return 0
+}
+
+func (x *Checkpoint) GetStageGenerations() int32 {
+	if x != nil {
+		return x.StageGenerations
+	}
+	return 0
+}
+
+func (x *Checkpoint) GetStageStreak() int32 {
+	if x != nil {
+		return x.StageStreak
+	}
+	return 0
+}
+
+func (x *Checkpoint) GetPlateau() int32 {
+	if x != nil {
+		return x.Plateau
+	}
+	return 0
+}
+
+func (x *Checkpoint) GetPlateauScore() float64 {
+	if x != nil {
+		return x.PlateauScore
+	}
+	return 0
+}
+
+func (x *Checkpoint) GetBoost() float64 {
+	if x != nil {
+		return x.Boost
+	}
+	return 0
+}
+
+func (x *Checkpoint) GetHallOfFame() []*Agent {
+	if x != nil {
+		return x.HallOfFame
+	}
+	return nil
+}
+
+func (x *Checkpoint) GetHalted() bool {
+	if x != nil {
+		return x.Halted
+	}
+	return false
+}
+
+type Behavior struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []float64              `protobuf:"fixed64,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Behavior) Reset() {
+	*x = Behavior{}
+	mi := &file_protos_genome_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Behavior) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Behavior) ProtoMessage() {}
+
+func (x *Behavior) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_genome_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Behavior.ProtoReflect.Descriptor instead.
+func (*Behavior) Descriptor() ([]byte, []int) {
+	return file_protos_genome_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Behavior) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type Agent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Genome        *Genome                `protobuf:"bytes,1,opt,name=genome,proto3" json:"genome,omitempty"`
+	Fitness       float64                `protobuf:"fixed64,2,opt,name=fitness,proto3" json:"fitness,omitempty"`
+	SharedFitness float64                `protobuf:"fixed64,3,opt,name=shared_fitness,json=sharedFitness,proto3" json:"shared_fitness,omitempty"`
+	Objectives    []float64              `protobuf:"fixed64,4,rep,packed,name=objectives,proto3" json:"objectives,omitempty"`
+	Rank          int32                  `protobuf:"varint,5,opt,name=rank,proto3" json:"rank,omitempty"`
+	Crowding      float64                `protobuf:"fixed64,6,opt,name=crowding,proto3" json:"crowding,omitempty"`
+	Behavior      []float64              `protobuf:"fixed64,7,rep,packed,name=behavior,proto3" json:"behavior,omitempty"`
+	Novelty       float64                `protobuf:"fixed64,8,opt,name=novelty,proto3" json:"novelty,omitempty"`
+	Score         float64                `protobuf:"fixed64,9,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Agent) Reset() {
+	*x = Agent{}
+	mi := &file_protos_genome_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Agent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Agent) ProtoMessage() {}
+
+func (x *Agent) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_genome_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Agent.ProtoReflect.Descriptor instead.
+func (*Agent) Descriptor() ([]byte, []int) {
+	return file_protos_genome_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Agent) GetGenome() *Genome {
+	if x != nil {
+		return x.Genome
+	}
+	return nil
+}
+
+func (x *Agent) GetFitness() float64 {
+	if x != nil {
+		return x.Fitness
+	}
+	return 0
+}
+
+func (x *Agent) GetSharedFitness() float64 {
+	if x != nil {
+		return x.SharedFitness
+	}
+	return 0
+}
+
+func (x *Agent) GetObjectives() []float64 {
+	if x != nil {
+		return x.Objectives
+	}
+	return nil
+}
+
+func (x *Agent) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *Agent) GetCrowding() float64 {
+	if x != nil {
+		return x.Crowding
+	}
+	return 0
+}
+
+func (x *Agent) GetBehavior() []float64 {
+	if x != nil {
+		return x.Behavior
+	}
+	return nil
+}
+
+func (x *Agent) GetNovelty() float64 {
+	if x != nil {
+		return x.Novelty
+	}
+	return 0
+}
+
+func (x *Agent) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type Species struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Representative *Genome                `protobuf:"bytes,2,opt,name=representative,proto3" json:"representative,omitempty"`
+	BestScore      float64                `protobuf:"fixed64,3,opt,name=best_score,json=bestScore,proto3" json:"best_score,omitempty"`
+	Stagnation     int32                  `protobuf:"varint,4,opt,name=stagnation,proto3" json:"stagnation,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Species) Reset() {
+	*x = Species{}
+	mi := &file_protos_genome_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Species) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Species) ProtoMessage() {}
+
+func (x *Species) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_genome_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Species.ProtoReflect.Descriptor instead.
+func (*Species) Descriptor() ([]byte, []int) {
+	return file_protos_genome_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Species) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Species) GetRepresentative() *Genome {
+	if x != nil {
+		return x.Representative
+	}
+	return nil
+}
+
+func (x *Species) GetBestScore() float64 {
+	if x != nil {
+		return x.BestScore
+	}
+	return 0
+}
+
+func (x *Species) GetStagnation() int32 {
+	if x != nil {
+		return x.Stagnation
+	}
+	return 0
+}
+
+type SimulationOptions struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	PopulationSize         int32                  `protobuf:"varint,1,opt,name=population_size,json=populationSize,proto3" json:"population_size,omitempty"`
+	MutationCount          int32                  `protobuf:"varint,2,opt,name=mutation_count,json=mutationCount,proto3" json:"mutation_count,omitempty"`
+	Iterations             int32                  `protobuf:"varint,3,opt,name=iterations,proto3" json:"iterations,omitempty"`
+	Threshold              int32                  `protobuf:"varint,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	ThresholdValue         float64                `protobuf:"fixed64,5,opt,name=threshold_value,json=thresholdValue,proto3" json:"threshold_value,omitempty"`
+	CrossoverRate          float64                `protobuf:"fixed64,6,opt,name=crossover_rate,json=crossoverRate,proto3" json:"crossover_rate,omitempty"`
+	Recurrent              bool                   `protobuf:"varint,7,opt,name=recurrent,proto3" json:"recurrent,omitempty"`
+	Mutation               *MutationConfig        `protobuf:"bytes,8,opt,name=mutation,proto3" json:"mutation,omitempty"`
+	GenerationTimeout      int64                  `protobuf:"varint,9,opt,name=generation_timeout,json=generationTimeout,proto3" json:"generation_timeout,omitempty"`
+	OutputActivation       string                 `protobuf:"bytes,10,opt,name=output_activation,json=outputActivation,proto3" json:"output_activation,omitempty"`
+	FixedOutputs           bool                   `protobuf:"varint,11,opt,name=fixed_outputs,json=fixedOutputs,proto3" json:"fixed_outputs,omitempty"`
+	CompatibilityThreshold float64                `protobuf:"fixed64,12,opt,name=compatibility_threshold,json=compatibilityThreshold,proto3" json:"compatibility_threshold,omitempty"`
+	ExcessCoefficient      float64                `protobuf:"fixed64,13,opt,name=excess_coefficient,json=excessCoefficient,proto3" json:"excess_coefficient,omitempty"`
+	DisjointCoefficient    float64                `protobuf:"fixed64,14,opt,name=disjoint_coefficient,json=disjointCoefficient,proto3" json:"disjoint_coefficient,omitempty"`
+	WeightCoefficient      float64                `protobuf:"fixed64,15,opt,name=weight_coefficient,json=weightCoefficient,proto3" json:"weight_coefficient,omitempty"`
+	StagnationLimit        int32                  `protobuf:"varint,16,opt,name=stagnation_limit,json=stagnationLimit,proto3" json:"stagnation_limit,omitempty"`
+	CheckpointDir          string                 `protobuf:"bytes,17,opt,name=checkpoint_dir,json=checkpointDir,proto3" json:"checkpoint_dir,omitempty"`
+	CheckpointEvery        int32                  `protobuf:"varint,18,opt,name=checkpoint_every,json=checkpointEvery,proto3" json:"checkpoint_every,omitempty"`
+	Elitism                int32                  `protobuf:"varint,19,opt,name=elitism,proto3" json:"elitism,omitempty"`
+	SurvivalFraction       float64                `protobuf:"fixed64,20,opt,name=survival_fraction,json=survivalFraction,proto3" json:"survival_fraction,omitempty"`
+	Workers                int32                  `protobuf:"varint,21,opt,name=workers,proto3" json:"workers,omitempty"`
+	AgentTimeout           int64                  `protobuf:"varint,22,opt,name=agent_timeout,json=agentTimeout,proto3" json:"agent_timeout,omitempty"`
+	Directions             []int32                `protobuf:"varint,23,rep,packed,name=directions,proto3" json:"directions,omitempty"`
+	NoveltyNeighbours      int32                  `protobuf:"varint,24,opt,name=novelty_neighbours,json=noveltyNeighbours,proto3" json:"novelty_neighbours,omitempty"`
+	NoveltyWeight          float64                `protobuf:"fixed64,25,opt,name=novelty_weight,json=noveltyWeight,proto3" json:"novelty_weight,omitempty"`
+	ArchiveThreshold       float64                `protobuf:"fixed64,26,opt,name=archive_threshold,json=archiveThreshold,proto3" json:"archive_threshold,omitempty"`
+	ArchiveSize            int32                  `protobuf:"varint,27,opt,name=archive_size,json=archiveSize,proto3" json:"archive_size,omitempty"`
+	Tolerance              float64                `protobuf:"fixed64,28,opt,name=tolerance,proto3" json:"tolerance,omitempty"`
+	StagnationGenerations  int32                  `protobuf:"varint,29,opt,name=stagnation_generations,json=stagnationGenerations,proto3" json:"stagnation_generations,omitempty"`
+	StagnationEpsilon      float64                `protobuf:"fixed64,30,opt,name=stagnation_epsilon,json=stagnationEpsilon,proto3" json:"stagnation_epsilon,omitempty"`
+	StagnationPolicy       int32                  `protobuf:"varint,31,opt,name=stagnation_policy,json=stagnationPolicy,proto3" json:"stagnation_policy,omitempty"`
+	MutationBoost          float64                `protobuf:"fixed64,32,opt,name=mutation_boost,json=mutationBoost,proto3" json:"mutation_boost,omitempty"`
+	InjectFraction         float64                `protobuf:"fixed64,33,opt,name=inject_fraction,json=injectFraction,proto3" json:"inject_fraction,omitempty"`
+	HallOfFameSize         int32                  `protobuf:"varint,34,opt,name=hall_of_fame_size,json=hallOfFameSize,proto3" json:"hall_of_fame_size,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SimulationOptions) Reset() {
+	*x = SimulationOptions{}
+	mi := &file_protos_genome_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulationOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulationOptions) ProtoMessage() {}
+
+func (x *SimulationOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_genome_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulationOptions.ProtoReflect.Descriptor instead.
+func (*SimulationOptions) Descriptor() ([]byte, []int) {
+	return file_protos_genome_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SimulationOptions) GetPopulationSize() int32 {
+	if x != nil {
+		return x.PopulationSize
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetMutationCount() int32 {
+	if x != nil {
+		return x.MutationCount
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetIterations() int32 {
+	if x != nil {
+		return x.Iterations
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetThresholdValue() float64 {
+	if x != nil {
+		return x.ThresholdValue
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetCrossoverRate() float64 {
+	if x != nil {
+		return x.CrossoverRate
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetRecurrent() bool {
+	if x != nil {
+		return x.Recurrent
+	}
+	return false
+}
+
+func (x *SimulationOptions) GetMutation() *MutationConfig {
+	if x != nil {
+		return x.Mutation
+	}
+	return nil
+}
+
+func (x *SimulationOptions) GetGenerationTimeout() int64 {
+	if x != nil {
+		return x.GenerationTimeout
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetOutputActivation() string {
+	if x != nil {
+		return x.OutputActivation
+	}
+	return ""
+}
+
+func (x *SimulationOptions) GetFixedOutputs() bool {
+	if x != nil {
+		return x.FixedOutputs
+	}
+	return false
+}
+
+func (x *SimulationOptions) GetCompatibilityThreshold() float64 {
+	if x != nil {
+		return x.CompatibilityThreshold
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetExcessCoefficient() float64 {
+	if x != nil {
+		return x.ExcessCoefficient
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetDisjointCoefficient() float64 {
+	if x != nil {
+		return x.DisjointCoefficient
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetWeightCoefficient() float64 {
+	if x != nil {
+		return x.WeightCoefficient
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetStagnationLimit() int32 {
+	if x != nil {
+		return x.StagnationLimit
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetCheckpointDir() string {
+	if x != nil {
+		return x.CheckpointDir
+	}
+	return ""
+}
+
+func (x *SimulationOptions) GetCheckpointEvery() int32 {
+	if x != nil {
+		return x.CheckpointEvery
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetElitism() int32 {
+	if x != nil {
+		return x.Elitism
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetSurvivalFraction() float64 {
+	if x != nil {
+		return x.SurvivalFraction
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetWorkers() int32 {
+	if x != nil {
+		return x.Workers
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetAgentTimeout() int64 {
+	if x != nil {
+		return x.AgentTimeout
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetDirections() []int32 {
+	if x != nil {
+		return x.Directions
+	}
+	return nil
+}
+
+func (x *SimulationOptions) GetNoveltyNeighbours() int32 {
+	if x != nil {
+		return x.NoveltyNeighbours
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetNoveltyWeight() float64 {
+	if x != nil {
+		return x.NoveltyWeight
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetArchiveThreshold() float64 {
+	if x != nil {
+		return x.ArchiveThreshold
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetArchiveSize() int32 {
+	if x != nil {
+		return x.ArchiveSize
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetTolerance() float64 {
+	if x != nil {
+		return x.Tolerance
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetStagnationGenerations() int32 {
+	if x != nil {
+		return x.StagnationGenerations
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetStagnationEpsilon() float64 {
+	if x != nil {
+		return x.StagnationEpsilon
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetStagnationPolicy() int32 {
+	if x != nil {
+		return x.StagnationPolicy
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetMutationBoost() float64 {
+	if x != nil {
+		return x.MutationBoost
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetInjectFraction() float64 {
+	if x != nil {
+		return x.InjectFraction
+	}
+	return 0
+}
+
+func (x *SimulationOptions) GetHallOfFameSize() int32 {
+	if x != nil {
+		return x.HallOfFameSize
+	}
+	return 0
+}
+
+type MutationConfig struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	SplitConnection       float64                `protobuf:"fixed64,1,opt,name=split_connection,json=splitConnection,proto3" json:"split_connection,omitempty"`
+	AddConnection         float64                `protobuf:"fixed64,2,opt,name=add_connection,json=addConnection,proto3" json:"add_connection,omitempty"`
+	ChangeWeight          float64                `protobuf:"fixed64,3,opt,name=change_weight,json=changeWeight,proto3" json:"change_weight,omitempty"`
+	ChangeBias            float64                `protobuf:"fixed64,4,opt,name=change_bias,json=changeBias,proto3" json:"change_bias,omitempty"`
+	ChangeActivation      float64                `protobuf:"fixed64,5,opt,name=change_activation,json=changeActivation,proto3" json:"change_activation,omitempty"`
+	RemoveConnection      float64                `protobuf:"fixed64,6,opt,name=remove_connection,json=removeConnection,proto3" json:"remove_connection,omitempty"`
+	RemoveNode            float64                `protobuf:"fixed64,7,opt,name=remove_node,json=removeNode,proto3" json:"remove_node,omitempty"`
+	AddConnectionAttempts int32                  `protobuf:"varint,8,opt,name=add_connection_attempts,json=addConnectionAttempts,proto3" json:"add_connection_attempts,omitempty"`
+	Sigma                 float64                `protobuf:"fixed64,9,opt,name=sigma,proto3" json:"sigma,omitempty"`
+	Replace               float64                `protobuf:"fixed64,10,opt,name=replace,proto3" json:"replace,omitempty"`
+	MinValue              float64                `protobuf:"fixed64,11,opt,name=min_value,json=minValue,proto3" json:"min_value,omitempty"`
+	MaxValue              float64                `protobuf:"fixed64,12,opt,name=max_value,json=maxValue,proto3" json:"max_value,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *MutationConfig) Reset() {
+	*x = MutationConfig{}
+	mi := &file_protos_genome_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MutationConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MutationConfig) ProtoMessage() {}
+
+func (x *MutationConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_genome_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MutationConfig.ProtoReflect.Descriptor instead.
+func (*MutationConfig) Descriptor() ([]byte, []int) {
+	return file_protos_genome_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MutationConfig) GetSplitConnection() float64 {
+	if x != nil {
+		return x.SplitConnection
+	}
+	return 0
+}
+
+func (x *MutationConfig) GetAddConnection() float64 {
+	if x != nil {
+		return x.AddConnection
+	}
+	return 0
+}
+
+func (x *MutationConfig) GetChangeWeight() float64 {
+	if x != nil {
+		return x.ChangeWeight
+	}
+	return 0
+}
+
+func (x *MutationConfig) GetChangeBias() float64 {
+	if x != nil {
+		return x.ChangeBias
+	}
+	return 0
+}
+
+func (x *MutationConfig) GetChangeActivation() float64 {
+	if x != nil {
+		return x.ChangeActivation
+	}
+	return 0
+}
+
+func (x *MutationConfig) GetRemoveConnection() float64 {
+	if x != nil {
+		return x.RemoveConnection
+	}
+	return 0
+}
+
+func (x *MutationConfig) GetRemoveNode() float64 {
+	if x != nil {
+		return x.RemoveNode
+	}
+	return 0
+}
+
+func (x *MutationConfig) GetAddConnectionAttempts() int32 {
+	if x != nil {
+		return x.AddConnectionAttempts
+	}
+	return 0
+}
+
+func (x *MutationConfig) GetSigma() float64 {
+	if x != nil {
+		return x.Sigma
+	}
+	return 0
+}
+
+func (x *MutationConfig) GetReplace() float64 {
+	if x != nil {
+		return x.Replace
+	}
+	return 0
+}
+
+func (x *MutationConfig) GetMinValue() float64 {
+	if x != nil {
+		return x.MinValue
+	}
+	return 0
+}
+
+func (x *MutationConfig) GetMaxValue() float64 {
+	if x != nil {
+		return x.MaxValue
+	}
+	return 0
+}
+
+type GenerationStats struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Generation      int32                  `protobuf:"varint,1,opt,name=generation,proto3" json:"generation,omitempty"`
+	Best            float64                `protobuf:"fixed64,2,opt,name=best,proto3" json:"best,omitempty"`
+	Mean            float64                `protobuf:"fixed64,3,opt,name=mean,proto3" json:"mean,omitempty"`
+	Median          float64                `protobuf:"fixed64,4,opt,name=median,proto3" json:"median,omitempty"`
+	Worst           float64                `protobuf:"fixed64,5,opt,name=worst,proto3" json:"worst,omitempty"`
+	Failed          int32                  `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+	MeanNodes       float64                `protobuf:"fixed64,7,opt,name=mean_nodes,json=meanNodes,proto3" json:"mean_nodes,omitempty"`
+	MeanConnections float64                `protobuf:"fixed64,8,opt,name=mean_connections,json=meanConnections,proto3" json:"mean_connections,omitempty"`
+	MaxNodes        int32                  `protobuf:"varint,9,opt,name=max_nodes,json=maxNodes,proto3" json:"max_nodes,omitempty"`
+	MaxConnections  int32                  `protobuf:"varint,10,opt,name=max_connections,json=maxConnections,proto3" json:"max_connections,omitempty"`
+	Species         int32                  `protobuf:"varint,11,opt,name=species,proto3" json:"species,omitempty"`
+	Duration        int64                  `protobuf:"varint,12,opt,name=duration,proto3" json:"duration,omitempty"`
+	Front           int32                  `protobuf:"varint,13,opt,name=front,proto3" json:"front,omitempty"`
+	Stage           int32                  `protobuf:"varint,14,opt,name=stage,proto3" json:"stage,omitempty"`
+	StageCompleted  bool                   `protobuf:"varint,15,opt,name=stage_completed,json=stageCompleted,proto3" json:"stage_completed,omitempty"`
+	Stagnated       bool                   `protobuf:"varint,16,opt,name=stagnated,proto3" json:"stagnated,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GenerationStats) Reset() {
+	*x = GenerationStats{}
+	mi := &file_protos_genome_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerationStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerationStats) ProtoMessage() {}
+
+func (x *GenerationStats) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_genome_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerationStats.ProtoReflect.Descriptor instead.
+func (*GenerationStats) Descriptor() ([]byte, []int) {
+	return file_protos_genome_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GenerationStats) GetGeneration() int32 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetBest() float64 {
+	if x != nil {
+		return x.Best
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetMean() float64 {
+	if x != nil {
+		return x.Mean
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetMedian() float64 {
+	if x != nil {
+		return x.Median
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetWorst() float64 {
+	if x != nil {
+		return x.Worst
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetMeanNodes() float64 {
+	if x != nil {
+		return x.MeanNodes
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetMeanConnections() float64 {
+	if x != nil {
+		return x.MeanConnections
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetMaxNodes() int32 {
+	if x != nil {
+		return x.MaxNodes
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetMaxConnections() int32 {
+	if x != nil {
+		return x.MaxConnections
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetSpecies() int32 {
+	if x != nil {
+		return x.Species
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetFront() int32 {
+	if x != nil {
+		return x.Front
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetStage() int32 {
+	if x != nil {
+		return x.Stage
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetStageCompleted() bool {
+	if x != nil {
+		return x.StageCompleted
+	}
+	return false
+}
+
+func (x *GenerationStats) GetStagnated() bool {
+	if x != nil {
+		return x.Stagnated
+	}
+	return false
+}
+
+type MapElitesArchive struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dimensions    []*Dimension           `protobuf:"bytes,1,rep,name=dimensions,proto3" json:"dimensions,omitempty"`
+	Elites        []*Elite               `protobuf:"bytes,2,rep,name=elites,proto3" json:"elites,omitempty"`
+	Innovations   *InnovationTracker     `protobuf:"bytes,3,opt,name=innovations,proto3" json:"innovations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapElitesArchive) Reset() {
+	*x = MapElitesArchive{}
+	mi := &file_protos_genome_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapElitesArchive) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapElitesArchive) ProtoMessage() {}
+
+func (x *MapElitesArchive) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_genome_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapElitesArchive.ProtoReflect.Descriptor instead.
+func (*MapElitesArchive) Descriptor() ([]byte, []int) {
+	return file_protos_genome_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MapElitesArchive) GetDimensions() []*Dimension {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+func (x *MapElitesArchive) GetElites() []*Elite {
+	if x != nil {
+		return x.Elites
+	}
+	return nil
+}
+
+func (x *MapElitesArchive) GetInnovations() *InnovationTracker {
+	if x != nil {
+		return x.Innovations
+	}
+	return nil
+}
+
+type Dimension struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Min           float64                `protobuf:"fixed64,2,opt,name=min,proto3" json:"min,omitempty"`
+	Max           float64                `protobuf:"fixed64,3,opt,name=max,proto3" json:"max,omitempty"`
+	Bins          int32                  `protobuf:"varint,4,opt,name=bins,proto3" json:"bins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dimension) Reset() {
+	*x = Dimension{}
+	mi := &file_protos_genome_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dimension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dimension) ProtoMessage() {}
+
+func (x *Dimension) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_genome_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dimension.ProtoReflect.Descriptor instead.
+func (*Dimension) Descriptor() ([]byte, []int) {
+	return file_protos_genome_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Dimension) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Dimension) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *Dimension) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *Dimension) GetBins() int32 {
+	if x != nil {
+		return x.Bins
+	}
+	return 0
+}
+
+type Elite struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cell          int32                  `protobuf:"varint,1,opt,name=cell,proto3" json:"cell,omitempty"`
+	Genome        *Genome                `protobuf:"bytes,2,opt,name=genome,proto3" json:"genome,omitempty"`
+	Fitness       float64                `protobuf:"fixed64,3,opt,name=fitness,proto3" json:"fitness,omitempty"`
+	Features      []float64              `protobuf:"fixed64,4,rep,packed,name=features,proto3" json:"features,omitempty"`
+	Score         float64                `protobuf:"fixed64,5,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Elite) Reset() {
+	*x = Elite{}
+	mi := &file_protos_genome_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Elite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Elite) ProtoMessage() {}
+
+func (x *Elite) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_genome_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Elite.ProtoReflect.Descriptor instead.
+func (*Elite) Descriptor() ([]byte, []int) {
+	return file_protos_genome_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Elite) GetCell() int32 {
+	if x != nil {
+		return x.Cell
+	}
+	return 0
+}
+
+func (x *Elite) GetGenome() *Genome {
+	if x != nil {
+		return x.Genome
+	}
+	return nil
+}
+
+func (x *Elite) GetFitness() float64 {
+	if x != nil {
+		return x.Fitness
+	}
+	return 0
+}
+
+func (x *Elite) GetFeatures() []float64 {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *Elite) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type EvaluationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Genomes       []*Genome              `protobuf:"bytes,1,rep,name=genomes,proto3" json:"genomes,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Seeds         []uint64               `protobuf:"varint,3,rep,packed,name=seeds,proto3" json:"seeds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluationRequest) Reset() {
+	*x = EvaluationRequest{}
+	mi := &file_protos_genome_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluationRequest) ProtoMessage() {}
+
+func (x *EvaluationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_genome_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluationRequest.ProtoReflect.Descriptor instead.
+func (*EvaluationRequest) Descriptor() ([]byte, []int) {
+	return file_protos_genome_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *EvaluationRequest) GetGenomes() []*Genome {
+	if x != nil {
+		return x.Genomes
+	}
+	return nil
+}
+
+func (x *EvaluationRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *EvaluationRequest) GetSeeds() []uint64 {
+	if x != nil {
+		return x.Seeds
+	}
+	return nil
+}
+
+type EvaluationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fitness       []float64              `protobuf:"fixed64,1,rep,packed,name=fitness,proto3" json:"fitness,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluationResponse) Reset() {
+	*x = EvaluationResponse{}
+	mi := &file_protos_genome_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluationResponse) ProtoMessage() {}
+
+func (x *EvaluationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_genome_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluationResponse.ProtoReflect.Descriptor instead.
+func (*EvaluationResponse) Descriptor() ([]byte, []int) {
+	return file_protos_genome_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *EvaluationResponse) GetFitness() []float64 {
+	if x != nil {
+		return x.Fitness
+	}
+	return nil
+}
+
+func (x *EvaluationResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type WorkerRegistration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerRegistration) Reset() {
+	*x = WorkerRegistration{}
+	mi := &file_protos_genome_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerRegistration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerRegistration) ProtoMessage() {}
+
+func (x *WorkerRegistration) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_genome_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerRegistration.ProtoReflect.Descriptor instead.
+func (*WorkerRegistration) Descriptor() ([]byte, []int) {
+	return file_protos_genome_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WorkerRegistration) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
 var File_protos_genome_proto protoreflect.FileDescriptor
 
 var file_protos_genome_proto_rawDesc = []byte{
 	0x0a, 0x13, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x67, 0x65, 0x6e, 0x6f, 0x6d, 0x65, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79, 0x61,
-	0x69, 0x22, 0xae, 0x01, 0x0a, 0x06, 0x47, 0x65, 0x6e, 0x6f, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x69, 0x22, 0x99, 0x02, 0x0a, 0x06, 0x47, 0x65, 0x6e, 0x6f, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
 	0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x69, 0x6e,
 	0x70, 0x75, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x18,
@@ -181,15 +1729,305 @@ var file_protos_genome_proto_rawDesc = []byte{
 	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
 	0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e,
 	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x22, 0x5a, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x6e,
-	0x12, 0x10, 0x0a, 0x03, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6f,
-	0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x69,
-	0x61, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x62, 0x69, 0x61, 0x73, 0x42, 0x27,
-	0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x61, 0x74,
-	0x77, 0x61, 0x74, 0x65, 0x2f, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79, 0x61, 0x69, 0x2f,
-	0x6c, 0x6f, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79, 0x61, 0x69, 0x2e, 0x4e,
+	0x6f, 0x64, 0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72,
+	0x65, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x69, 0x78, 0x65,
+	0x64, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x66, 0x69, 0x78, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x22, 0x7a, 0x0a,
+	0x0a, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6f,
+	0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x69, 0x61, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x04, 0x62, 0x69, 0x61, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x6e,
+	0x6f, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x69,
+	0x6e, 0x6e, 0x6f, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x36, 0x0a, 0x04, 0x4e, 0x6f, 0x64,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0xb5, 0x01, 0x0a, 0x11, 0x49, 0x6e, 0x6e, 0x6f, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6e, 0x65, 0x78,
+	0x74, 0x5f, 0x69, 0x6e, 0x6e, 0x6f, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0e, 0x6e, 0x65, 0x78, 0x74, 0x49, 0x6e, 0x6e, 0x6f, 0x76, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x65, 0x78, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x61, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70,
+	0x61, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x72, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x70, 0x61, 0x72, 0x74, 0x73, 0x22, 0x9b, 0x06, 0x0a, 0x0a, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x67, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x0a, 0x70, 0x6f, 0x70, 0x75,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73,
+	0x6f, 0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79, 0x61, 0x69, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52,
+	0x0a, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3f, 0x0a, 0x0b, 0x69,
+	0x6e, 0x6e, 0x6f, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79, 0x61, 0x69, 0x2e, 0x49, 0x6e,
+	0x6e, 0x6f, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52,
+	0x0b, 0x69, 0x6e, 0x6e, 0x6f, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x07,
+	0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x73, 0x6f, 0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79, 0x61, 0x69, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x69,
+	0x65, 0x73, 0x52, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6e,
+	0x65, 0x78, 0x74, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x53, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x12, 0x1d,
+	0x0a, 0x0a, 0x62, 0x65, 0x73, 0x74, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x09, 0x62, 0x65, 0x73, 0x74, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x37, 0x0a,
+	0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79, 0x61, 0x69, 0x2e, 0x53, 0x69, 0x6d, 0x75,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x35, 0x0a, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x69,
+	0x6e, 0x79, 0x61, 0x69, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x72, 0x6e, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x72, 0x6e, 0x67, 0x12,
+	0x25, 0x0a, 0x04, 0x62, 0x65, 0x73, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x73, 0x6f, 0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79, 0x61, 0x69, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x52, 0x04, 0x62, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x05, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x18,
+	0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79,
+	0x61, 0x69, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x12,
+	0x2e, 0x0a, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79, 0x61, 0x69, 0x2e, 0x42, 0x65,
+	0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x52, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x74, 0x61, 0x67, 0x65, 0x5f, 0x67,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x10, 0x73, 0x74, 0x61, 0x67, 0x65, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6b, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x67, 0x65, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x61, 0x75,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x61, 0x75, 0x12,
+	0x23, 0x0a, 0x0d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x61, 0x75, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x18, 0x11, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x61, 0x75, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6f, 0x6f, 0x73, 0x74, 0x18, 0x12, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x62, 0x6f, 0x6f, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x0c, 0x68, 0x61,
+	0x6c, 0x6c, 0x5f, 0x6f, 0x66, 0x5f, 0x66, 0x61, 0x6d, 0x65, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79, 0x61, 0x69, 0x2e, 0x41, 0x67,
+	0x65, 0x6e, 0x74, 0x52, 0x0a, 0x68, 0x61, 0x6c, 0x6c, 0x4f, 0x66, 0x46, 0x61, 0x6d, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x68, 0x61, 0x6c, 0x74, 0x65, 0x64, 0x18, 0x14, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x68, 0x61, 0x6c, 0x74, 0x65, 0x64, 0x22, 0x22, 0x0a, 0x08, 0x42, 0x65, 0x68, 0x61, 0x76,
+	0x69, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x01, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x90, 0x02, 0x0a, 0x05,
+	0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x06, 0x67, 0x65, 0x6e, 0x6f, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79,
+	0x61, 0x69, 0x2e, 0x47, 0x65, 0x6e, 0x6f, 0x6d, 0x65, 0x52, 0x06, 0x67, 0x65, 0x6e, 0x6f, 0x6d,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x07, 0x66, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0d, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x46, 0x69, 0x74, 0x6e, 0x65,
+	0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0a, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x72, 0x6f, 0x77, 0x64, 0x69,
+	0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x63, 0x72, 0x6f, 0x77, 0x64, 0x69,
+	0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x01, 0x52, 0x08, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x12, 0x18,
+	0x0a, 0x07, 0x6e, 0x6f, 0x76, 0x65, 0x6c, 0x74, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x07, 0x6e, 0x6f, 0x76, 0x65, 0x6c, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x94,
+	0x01, 0x0a, 0x07, 0x53, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3a, 0x0a, 0x0e, 0x72, 0x65,
+	0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79, 0x61, 0x69, 0x2e,
+	0x47, 0x65, 0x6e, 0x6f, 0x6d, 0x65, 0x52, 0x0e, 0x72, 0x65, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e,
+	0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x65, 0x73, 0x74, 0x5f, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x62, 0x65, 0x73, 0x74,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x67, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x67, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x87, 0x0b, 0x0a, 0x11, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x70,
+	0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6d, 0x75,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x69,
+	0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
+	0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0e, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x6f, 0x76, 0x65, 0x72, 0x5f,
+	0x72, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x63, 0x72, 0x6f, 0x73,
+	0x73, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x36, 0x0a, 0x08, 0x6d, 0x75, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x6f, 0x6d, 0x65,
+	0x74, 0x69, 0x6e, 0x79, 0x61, 0x69, 0x2e, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x08, 0x6d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x2d, 0x0a, 0x12, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x67, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x2b,
+	0x0a, 0x11, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x66,
+	0x69, 0x78, 0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0c, 0x66, 0x69, 0x78, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73,
+	0x12, 0x37, 0x0a, 0x17, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x16, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x65, 0x78, 0x63,
+	0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x65, 0x66, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x74, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x65, 0x78, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6f, 0x65,
+	0x66, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x31, 0x0a, 0x14, 0x64, 0x69, 0x73, 0x6a,
+	0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x65, 0x66, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x74,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x01, 0x52, 0x13, 0x64, 0x69, 0x73, 0x6a, 0x6f, 0x69, 0x6e, 0x74,
+	0x43, 0x6f, 0x65, 0x66, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x12, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x63, 0x6f, 0x65, 0x66, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e,
+	0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x43,
+	0x6f, 0x65, 0x66, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x74,
+	0x61, 0x67, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x67, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x44, 0x69, 0x72, 0x12, 0x29, 0x0a, 0x10,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x65, 0x76, 0x65, 0x72, 0x79,
+	0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x45, 0x76, 0x65, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6c, 0x69, 0x74, 0x69,
+	0x73, 0x6d, 0x18, 0x13, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x65, 0x6c, 0x69, 0x74, 0x69, 0x73,
+	0x6d, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x75, 0x72, 0x76, 0x69, 0x76, 0x61, 0x6c, 0x5f, 0x66, 0x72,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x14, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x73, 0x75,
+	0x72, 0x76, 0x69, 0x76, 0x61, 0x6c, 0x46, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18,
+	0x0a, 0x07, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x16, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0c, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x1e, 0x0a,
+	0x0a, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x17, 0x20, 0x03, 0x28,
+	0x05, 0x52, 0x0a, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a,
+	0x12, 0x6e, 0x6f, 0x76, 0x65, 0x6c, 0x74, 0x79, 0x5f, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f,
+	0x75, 0x72, 0x73, 0x18, 0x18, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x6e, 0x6f, 0x76, 0x65, 0x6c,
+	0x74, 0x79, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x75, 0x72, 0x73, 0x12, 0x25, 0x0a, 0x0e,
+	0x6e, 0x6f, 0x76, 0x65, 0x6c, 0x74, 0x79, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x19,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x6e, 0x6f, 0x76, 0x65, 0x6c, 0x74, 0x79, 0x57, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x5f, 0x74,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10,
+	0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x1b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65,
+	0x18, 0x1c, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x35, 0x0a, 0x16, 0x73, 0x74, 0x61, 0x67, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x1d, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x15, 0x73, 0x74, 0x61, 0x67, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x47, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x73, 0x74, 0x61, 0x67,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x70, 0x73, 0x69, 0x6c, 0x6f, 0x6e, 0x18, 0x1e,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x73, 0x74, 0x61, 0x67, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x45, 0x70, 0x73, 0x69, 0x6c, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x74, 0x61, 0x67, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x1f, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x10, 0x73, 0x74, 0x61, 0x67, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x62, 0x6f, 0x6f, 0x73, 0x74, 0x18, 0x20, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x6d, 0x75,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x6f, 0x6f, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x69,
+	0x6e, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x66, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x21,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x69, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x46, 0x72, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x11, 0x68, 0x61, 0x6c, 0x6c, 0x5f, 0x6f, 0x66, 0x5f,
+	0x66, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x22, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0e, 0x68, 0x61, 0x6c, 0x6c, 0x4f, 0x66, 0x46, 0x61, 0x6d, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22,
+	0xc5, 0x03, 0x0a, 0x0e, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x73, 0x70,
+	0x6c, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a,
+	0x0e, 0x61, 0x64, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x61, 0x64, 0x64, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x5f, 0x62, 0x69, 0x61, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x69, 0x61, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x63, 0x74,
+	0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x10, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x6e,
+	0x6f, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x36, 0x0a, 0x17, 0x61, 0x64, 0x64, 0x5f, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x15, 0x61, 0x64, 0x64, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x69, 0x67, 0x6d, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x69,
+	0x67, 0x6d, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x08, 0x6d, 0x69, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61,
+	0x78, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6d,
+	0x61, 0x78, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xd8, 0x03, 0x0a, 0x0f, 0x47, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x67,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x62,
+	0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x62, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6d, 0x65, 0x61, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6d,
+	0x65, 0x61, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x06, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x77,
+	0x6f, 0x72, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x65, 0x61,
+	0x6e, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6d,
+	0x65, 0x61, 0x6e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x6d, 0x65, 0x61, 0x6e,
+	0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0f, 0x6d, 0x65, 0x61, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x4e, 0x6f, 0x64, 0x65, 0x73,
+	0x12, 0x27, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x70, 0x65,
+	0x63, 0x69, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x73, 0x70, 0x65, 0x63,
+	0x69, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x66, 0x72, 0x6f, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x73,
+	0x74, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x74, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x67, 0x6e, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x74, 0x61, 0x67, 0x6e, 0x61, 0x74,
+	0x65, 0x64, 0x22, 0xb5, 0x01, 0x0a, 0x10, 0x4d, 0x61, 0x70, 0x45, 0x6c, 0x69, 0x74, 0x65, 0x73,
+	0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x64, 0x69, 0x6d, 0x65, 0x6e,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x6f,
+	0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79, 0x61, 0x69, 0x2e, 0x44, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x0a, 0x64, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x29,
+	0x0a, 0x06, 0x65, 0x6c, 0x69, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79, 0x61, 0x69, 0x2e, 0x45, 0x6c, 0x69, 0x74,
+	0x65, 0x52, 0x06, 0x65, 0x6c, 0x69, 0x74, 0x65, 0x73, 0x12, 0x3f, 0x0a, 0x0b, 0x69, 0x6e, 0x6e,
+	0x6f, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79, 0x61, 0x69, 0x2e, 0x49, 0x6e, 0x6e, 0x6f,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x0b, 0x69,
+	0x6e, 0x6e, 0x6f, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x57, 0x0a, 0x09, 0x44, 0x69,
+	0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d,
+	0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a,
+	0x03, 0x6d, 0x61, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12,
+	0x12, 0x0a, 0x04, 0x62, 0x69, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x62,
+	0x69, 0x6e, 0x73, 0x22, 0x93, 0x01, 0x0a, 0x05, 0x45, 0x6c, 0x69, 0x74, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x63, 0x65, 0x6c, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x65, 0x6c,
+	0x6c, 0x12, 0x2a, 0x0a, 0x06, 0x67, 0x65, 0x6e, 0x6f, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79, 0x61, 0x69, 0x2e, 0x47,
+	0x65, 0x6e, 0x6f, 0x6d, 0x65, 0x52, 0x06, 0x67, 0x65, 0x6e, 0x6f, 0x6d, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x66, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07,
+	0x66, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x01, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x6b, 0x0a, 0x11, 0x45, 0x76, 0x61,
+	0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c,
+	0x0a, 0x07, 0x67, 0x65, 0x6e, 0x6f, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79, 0x61, 0x69, 0x2e, 0x47, 0x65, 0x6e,
+	0x6f, 0x6d, 0x65, 0x52, 0x07, 0x67, 0x65, 0x6e, 0x6f, 0x6d, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x65, 0x65, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x04, 0x52,
+	0x05, 0x73, 0x65, 0x65, 0x64, 0x73, 0x22, 0x44, 0x0a, 0x12, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x66, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x01, 0x52, 0x07, 0x66,
+	0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x2e, 0x0a, 0x12,
+	0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x42, 0x27, 0x5a, 0x25,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x61, 0x74, 0x77, 0x61,
+	0x74, 0x65, 0x2f, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x69, 0x6e, 0x79, 0x61, 0x69, 0x2f, 0x6c, 0x6f,
+	0x61, 0x64, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -205,20 +2043,53 @@ func file_protos_genome_proto_rawDescGZIP() []byte {
 }
 
 var (
-	file_protos_genome_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+	file_protos_genome_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 	file_protos_genome_proto_goTypes  = []any{
-		(*Genome)(nil),     // 0: sometinyai.Genome
-		(*Connection)(nil), // 1: sometinyai.Connection
+		(*Genome)(nil),             // 0: sometinyai.Genome
+		(*Connection)(nil),         // 1: sometinyai.Connection
+		(*Node)(nil),               // 2: sometinyai.Node
+		(*InnovationTracker)(nil),  // 3: sometinyai.InnovationTracker
+		(*Checkpoint)(nil),         // 4: sometinyai.Checkpoint
+		(*Behavior)(nil),           // 5: sometinyai.Behavior
+		(*Agent)(nil),              // 6: sometinyai.Agent
+		(*Species)(nil),            // 7: sometinyai.Species
+		(*SimulationOptions)(nil),  // 8: sometinyai.SimulationOptions
+		(*MutationConfig)(nil),     // 9: sometinyai.MutationConfig
+		(*GenerationStats)(nil),    // 10: sometinyai.GenerationStats
+		(*MapElitesArchive)(nil),   // 11: sometinyai.MapElitesArchive
+		(*Dimension)(nil),          // 12: sometinyai.Dimension
+		(*Elite)(nil),              // 13: sometinyai.Elite
+		(*EvaluationRequest)(nil),  // 14: sometinyai.EvaluationRequest
+		(*EvaluationResponse)(nil), // 15: sometinyai.EvaluationResponse
+		(*WorkerRegistration)(nil), // 16: sometinyai.WorkerRegistration
 	}
 )
 
 var file_protos_genome_proto_depIdxs = []int32{
-	1, // 0: sometinyai.Genome.connections:type_name -> sometinyai.Connection
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	1,  // 0: sometinyai.Genome.connections:type_name -> sometinyai.Connection
+	2,  // 1: sometinyai.Genome.nodes:type_name -> sometinyai.Node
+	6,  // 2: sometinyai.Checkpoint.population:type_name -> sometinyai.Agent
+	3,  // 3: sometinyai.Checkpoint.innovations:type_name -> sometinyai.InnovationTracker
+	7,  // 4: sometinyai.Checkpoint.species:type_name -> sometinyai.Species
+	8,  // 5: sometinyai.Checkpoint.options:type_name -> sometinyai.SimulationOptions
+	10, // 6: sometinyai.Checkpoint.history:type_name -> sometinyai.GenerationStats
+	6,  // 7: sometinyai.Checkpoint.best:type_name -> sometinyai.Agent
+	6,  // 8: sometinyai.Checkpoint.front:type_name -> sometinyai.Agent
+	5,  // 9: sometinyai.Checkpoint.archive:type_name -> sometinyai.Behavior
+	6,  // 10: sometinyai.Checkpoint.hall_of_fame:type_name -> sometinyai.Agent
+	0,  // 11: sometinyai.Agent.genome:type_name -> sometinyai.Genome
+	0,  // 12: sometinyai.Species.representative:type_name -> sometinyai.Genome
+	9,  // 13: sometinyai.SimulationOptions.mutation:type_name -> sometinyai.MutationConfig
+	12, // 14: sometinyai.MapElitesArchive.dimensions:type_name -> sometinyai.Dimension
+	13, // 15: sometinyai.MapElitesArchive.elites:type_name -> sometinyai.Elite
+	3,  // 16: sometinyai.MapElitesArchive.innovations:type_name -> sometinyai.InnovationTracker
+	0,  // 17: sometinyai.Elite.genome:type_name -> sometinyai.Genome
+	0,  // 18: sometinyai.EvaluationRequest.genomes:type_name -> sometinyai.Genome
+	19, // [19:19] is the sub-list for method output_type
+	19, // [19:19] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_protos_genome_proto_init() }
@@ -232,7 +2103,7 @@ func file_protos_genome_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protos_genome_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
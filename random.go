@@ -0,0 +1,48 @@
+package sometinyai
+
+import (
+	"math/rand/v2"
+	"slices"
+)
+
+// globalSource draws from the global generator of math/rand/v2, so genomes
+// without a source of their own can share it between goroutines.
+type globalSource struct{}
+
+func (globalSource) Uint64() uint64 { return rand.Uint64() }
+
+var globalRand = rand.New(globalSource{})
+
+// WithRand makes every random choice of the genome, from the initial weights
+// to its mutations, come from r. Genomes given the same seeded source evolve
+// the same way. r is not safe for concurrent use, so a genome mutated in its
+// own goroutine needs a source of its own.
+func WithRand(r *rand.Rand) GenomeOption {
+	return func(g *Genome) { g.rng = r }
+}
+
+// SetRand replaces the random source of the genome, see WithRand. A nil r
+// makes it use the global generator of math/rand/v2.
+func (g *Genome) SetRand(r *rand.Rand) {
+	g.rng = r
+}
+
+// Rand returns the random source of the genome. Fitness functions that need
+// randomness can draw from it to stay reproducible.
+func (g *Genome) Rand() *rand.Rand {
+	if g.rng == nil {
+		return globalRand
+	}
+	return g.rng
+}
+
+// randomKey returns a random key of m. Keys are sorted before drawing, map
+// iteration order would make the choice differ between runs.
+func randomKey[T interface{ ~int }, Y any](r *rand.Rand, m map[T]Y) T {
+	keys := make([]T, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys[r.IntN(len(keys))]
+}
@@ -0,0 +1,78 @@
+package sometinyai
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Recurrent makes the genome allow cycles and self loops, and keep the value
+// of every node between calls to Step, so it can evolve memory.
+func Recurrent() GenomeOption {
+	return func(g *Genome) { g.recurrent = true }
+}
+
+// Recurrent reports whether the genome was created with the Recurrent option.
+func (g *Genome) Recurrent() bool {
+	return g.recurrent
+}
+
+// Step feeds one input to the genome and advances its state. Connections
+// coming from nodes that are evaluated later in the pass, or from the node
+// itself, carry the value of the previous step. The state starts at zero and
+// is cleared by Reset and by any mutation.
+func (g *Genome) Step(input ...float64) []float64 {
+	if len(input) != g.input {
+		panic(fmt.Sprintf("Expected %d inputs, got %d", g.input, len(input)))
+	}
+
+	if g.plan == nil {
+		if err := g.Compile(); err != nil {
+			panic(err)
+		}
+	}
+	if g.state == nil {
+		g.state = make([]float64, g.plan.size)
+	}
+	return g.plan.run(g.state, input)
+}
+
+// Reset clears the state kept by Step.
+func (g *Genome) Reset() {
+	clear(g.state)
+}
+
+// recurrentOrder is the evaluation order of a graph that may have cycles: the
+// reverse postorder of a depth first search started from the inputs, and then
+// from any node they cannot reach. It is a topological order once the edges
+// closing a cycle are left out, so only those carry the previous step's
+// values.
+func (g *Genome) recurrentOrder() ([]int, error) {
+	adj, err := g.graph.AdjacencyMap()
+	if err != nil {
+		return nil, err
+	}
+
+	postorder := make([]int, 0, len(adj))
+	visited := make(map[int]bool, len(adj))
+	var visit func(node int)
+	visit = func(node int) {
+		visited[node] = true
+		for _, next := range slices.Sorted(maps.Keys(adj[node])) {
+			if !visited[next] {
+				visit(next)
+			}
+		}
+		postorder = append(postorder, node)
+	}
+	for i := range g.input {
+		visit(i)
+	}
+	for _, node := range slices.Sorted(maps.Keys(adj)) {
+		if !visited[node] {
+			visit(node)
+		}
+	}
+	slices.Reverse(postorder)
+	return postorder, nil
+}
@@ -0,0 +1,443 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/matwate/sometinyai"
+	"github.com/matwate/sometinyai/activation"
+	pb "github.com/matwate/sometinyai/protos"
+)
+
+// Checkpoint makes the training write a checkpoint to dir every given number
+// of generations, named after the generation it resumes from.
+func Checkpoint(dir string, every int) Option {
+	return func(o *Settings) {
+		o.CheckpointDir = dir
+		o.CheckpointEvery = every
+	}
+}
+
+// SaveCheckpoint writes everything needed to resume the training: the
+// population with its fitness, the generation counter, the state of the
+// random generator, the innovation, species, novelty archive, curriculum and
+// stagnation state, the history and the options that can be serialized. The fitness functions,
+// coordinator, mutable data, callbacks, observers, logger and selector are
+// not saved, they are given again to LoadCheckpoint.
+func (s *Simulation[D]) SaveCheckpoint(w io.Writer) error {
+	return s.saveCheckpoint(w, s.Generation)
+}
+
+func (s *Simulation[D]) saveCheckpoint(w io.Writer, generation int) error {
+	checkpoint := &pb.Checkpoint{
+		Generation:  int32(generation),
+		Innovations: s.Innovations.ToProto(),
+		NextSpecies: int32(s.nextSpecies),
+		BestScore:   s.bestScore,
+
+		Stage:            int32(s.stage),
+		StageGenerations: int32(s.stageGenerations),
+		StageStreak:      int32(s.stageStreak),
+
+		Plateau:      int32(s.plateau),
+		PlateauScore: s.plateauScore,
+		Boost:        s.boost,
+		Halted:       s.halted,
+	}
+	var err error
+	if checkpoint.Options, err = s.Config.toProto(); err != nil {
+		return err
+	}
+	if checkpoint.Rng, err = s.pcg.MarshalBinary(); err != nil {
+		return err
+	}
+	for _, agent := range s.Population {
+		m, err := agent.toProto()
+		if err != nil {
+			return err
+		}
+		checkpoint.Population = append(checkpoint.Population, m)
+	}
+	if s.best.Genome != nil {
+		if checkpoint.Best, err = s.best.toProto(); err != nil {
+			return err
+		}
+	}
+	for _, agent := range s.front {
+		m, err := agent.toProto()
+		if err != nil {
+			return err
+		}
+		checkpoint.Front = append(checkpoint.Front, m)
+	}
+	for _, sp := range s.Species {
+		representative, err := sp.Representative.ToProto()
+		if err != nil {
+			return err
+		}
+		checkpoint.Species = append(checkpoint.Species, &pb.Species{
+			Id:             int32(sp.ID),
+			Representative: representative,
+			BestScore:      sp.BestScore,
+			Stagnation:     int32(sp.Stagnation),
+		})
+	}
+	for _, agent := range s.hallOfFame {
+		m, err := agent.toProto()
+		if err != nil {
+			return err
+		}
+		checkpoint.HallOfFame = append(checkpoint.HallOfFame, m)
+	}
+	for _, stats := range s.History {
+		checkpoint.History = append(checkpoint.History, stats.toProto())
+	}
+	for _, behavior := range s.archive {
+		checkpoint.Archive = append(checkpoint.Archive, &pb.Behavior{Values: behavior})
+	}
+
+	out, err := proto.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// LoadCheckpoint restores an untyped simulation saved with SaveCheckpoint,
+// see LoadTypedCheckpoint.
+func LoadCheckpoint(r io.Reader, opts ...Option) (Simulation[interface{}], error) {
+	return LoadTypedCheckpoint(r, untyped(opts)...)
+}
+
+// LoadTypedCheckpoint restores a simulation saved with SaveCheckpoint. The
+// saved options are applied first, then opts, which have to provide the
+// fitness function and whatever else could not be saved.
+func LoadTypedCheckpoint[D any](r io.Reader, opts ...TypedOption[D]) (Simulation[D], error) {
+	in, err := io.ReadAll(r)
+	if err != nil {
+		return Simulation[D]{}, err
+	}
+	checkpoint := &pb.Checkpoint{}
+	if err := proto.Unmarshal(in, checkpoint); err != nil {
+		return Simulation[D]{}, err
+	}
+
+	settings, err := settingsFromProto(checkpoint.GetOptions())
+	if err != nil {
+		return Simulation[D]{}, err
+	}
+
+	s := Simulation[D]{
+		Config:      newOptions[D](settings, opts),
+		Innovations: sometinyai.InnovationTrackerFromProto(checkpoint.GetInnovations()),
+		Generation:  int(checkpoint.GetGeneration()),
+		nextSpecies: int(checkpoint.GetNextSpecies()),
+		bestScore:   checkpoint.GetBestScore(),
+		pcg:         rand.NewPCG(rand.Uint64(), rand.Uint64()),
+		inputs:      int(checkpoint.GetInnovations().GetInputs()),
+		outputs:     int(checkpoint.GetInnovations().GetOutputs()),
+
+		stage:            int(checkpoint.GetStage()),
+		stageGenerations: int(checkpoint.GetStageGenerations()),
+		stageStreak:      int(checkpoint.GetStageStreak()),
+
+		plateau:      int(checkpoint.GetPlateau()),
+		plateauScore: checkpoint.GetPlateauScore(),
+		boost:        checkpoint.GetBoost(),
+		halted:       checkpoint.GetHalted(),
+	}
+	if len(checkpoint.GetRng()) > 0 {
+		if err := s.pcg.UnmarshalBinary(checkpoint.GetRng()); err != nil {
+			return Simulation[D]{}, err
+		}
+	}
+	s.rng = rand.New(s.pcg)
+	genome := func(m *pb.Genome) (*sometinyai.Genome, error) {
+		return sometinyai.GenomeFromProto(m, sometinyai.WithInnovationTracker(s.Innovations))
+	}
+	agent := func(m *pb.Agent) (Agent, error) {
+		g, err := genome(m.GetGenome())
+		if err != nil {
+			return Agent{}, err
+		}
+		return Agent{
+			Genome:        g,
+			Fitness:       m.GetFitness(),
+			SharedFitness: m.GetSharedFitness(),
+			Objectives:    m.GetObjectives(),
+			Rank:          int(m.GetRank()),
+			Crowding:      m.GetCrowding(),
+			Behavior:      m.GetBehavior(),
+			Novelty:       m.GetNovelty(),
+			Score:         m.GetScore(),
+		}, nil
+	}
+	for i, m := range checkpoint.GetPopulation() {
+		a, err := agent(m)
+		if err != nil {
+			return Simulation[D]{}, fmt.Errorf("agent %d: %w", i, err)
+		}
+		s.Population = append(s.Population, a)
+	}
+	if len(s.Population) == 0 {
+		return Simulation[D]{}, errors.New("simulation: checkpoint has no population")
+	}
+	if a, ok := activation.Lookup(checkpoint.GetPopulation()[0].GetGenome().GetActivation()); ok {
+		s.act = a.Func()
+	}
+	if checkpoint.GetBest() != nil {
+		if s.best, err = agent(checkpoint.GetBest()); err != nil {
+			return Simulation[D]{}, fmt.Errorf("best agent: %w", err)
+		}
+	}
+	for i, m := range checkpoint.GetFront() {
+		a, err := agent(m)
+		if err != nil {
+			return Simulation[D]{}, fmt.Errorf("front agent %d: %w", i, err)
+		}
+		s.front = append(s.front, a)
+	}
+	for _, sp := range checkpoint.GetSpecies() {
+		representative, err := genome(sp.GetRepresentative())
+		if err != nil {
+			return Simulation[D]{}, fmt.Errorf("species %d: %w", sp.GetId(), err)
+		}
+		s.Species = append(s.Species, &Species{
+			ID:             int(sp.GetId()),
+			Representative: representative,
+			BestScore:      sp.GetBestScore(),
+			Stagnation:     int(sp.GetStagnation()),
+		})
+	}
+	for i, m := range checkpoint.GetHallOfFame() {
+		a, err := agent(m)
+		if err != nil {
+			return Simulation[D]{}, fmt.Errorf("hall of fame agent %d: %w", i, err)
+		}
+		s.hallOfFame = append(s.hallOfFame, a)
+	}
+	for _, stats := range checkpoint.GetHistory() {
+		s.History = append(s.History, statsFromProto(stats))
+	}
+	for _, behavior := range checkpoint.GetArchive() {
+		s.archive = append(s.archive, behavior.GetValues())
+	}
+	// The mutable data of the current stage was not saved
+	s.applyStage()
+	return s, nil
+}
+
+// checkpoint writes the checkpoint of the given generation to the checkpoint
+// directory. The file is renamed into place once complete, so an interrupted
+// write never replaces a good checkpoint.
+func (s *Simulation[D]) checkpoint(generation int) error {
+	if err := os.MkdirAll(s.Config.CheckpointDir, 0755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(s.Config.CheckpointDir, "checkpoint-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := s.saveCheckpoint(f, generation); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	name := filepath.Join(s.Config.CheckpointDir, fmt.Sprintf("checkpoint-%06d.pb", generation))
+	s.logger().Debug("saving checkpoint", "file", name, "generation", generation)
+	return os.Rename(f.Name(), name)
+}
+
+func (a Agent) toProto() (*pb.Agent, error) {
+	genome, err := a.Genome.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Agent{
+		Genome:        genome,
+		Fitness:       a.Fitness,
+		SharedFitness: a.SharedFitness,
+		Objectives:    a.Objectives,
+		Rank:          int32(a.Rank),
+		Crowding:      a.Crowding,
+		Behavior:      a.Behavior,
+		Novelty:       a.Novelty,
+		Score:         a.Score,
+	}, nil
+}
+
+func (o *Settings) toProto() (*pb.SimulationOptions, error) {
+	m := &pb.SimulationOptions{
+		PopulationSize:         int32(o.PopulationSize),
+		MutationCount:          int32(o.MutationCount),
+		Iterations:             int32(o.Iterations),
+		Threshold:              int32(o.Threshold),
+		ThresholdValue:         o.ThresholdValue,
+		Tolerance:              o.Tolerance,
+		CrossoverRate:          o.CrossoverRate,
+		Recurrent:              o.Recurrent,
+		GenerationTimeout:      int64(o.generationTimeout),
+		FixedOutputs:           o.FixedOutputs,
+		CompatibilityThreshold: o.CompatibilityThreshold,
+		ExcessCoefficient:      o.ExcessCoefficient,
+		DisjointCoefficient:    o.DisjointCoefficient,
+		WeightCoefficient:      o.WeightCoefficient,
+		StagnationLimit:        int32(o.StagnationLimit),
+		CheckpointDir:          o.CheckpointDir,
+		CheckpointEvery:        int32(o.CheckpointEvery),
+		Elitism:                int32(o.Elitism),
+		SurvivalFraction:       o.SurvivalFraction,
+		Workers:                int32(o.Workers),
+		AgentTimeout:           int64(o.AgentTimeout),
+		NoveltyNeighbours:      int32(o.NoveltyNeighbours),
+		NoveltyWeight:          o.NoveltyWeight,
+		ArchiveThreshold:       o.ArchiveThreshold,
+		ArchiveSize:            int32(o.ArchiveSize),
+		StagnationGenerations:  int32(o.StagnationGenerations),
+		StagnationEpsilon:      o.StagnationEpsilon,
+		StagnationPolicy:       int32(o.StagnationPolicy),
+		MutationBoost:          o.MutationBoost,
+		InjectFraction:         o.InjectFraction,
+		HallOfFameSize:         int32(o.HallOfFameSize),
+		Mutation: &pb.MutationConfig{
+			SplitConnection:       o.Mutation.SplitConnection,
+			AddConnection:         o.Mutation.AddConnection,
+			ChangeWeight:          o.Mutation.ChangeWeight,
+			ChangeBias:            o.Mutation.ChangeBias,
+			ChangeActivation:      o.Mutation.ChangeActivation,
+			RemoveConnection:      o.Mutation.RemoveConnection,
+			RemoveNode:            o.Mutation.RemoveNode,
+			AddConnectionAttempts: int32(o.Mutation.AddConnectionAttempts),
+			Sigma:                 o.Mutation.Sigma,
+			Replace:               o.Mutation.Replace,
+			MinValue:              o.Mutation.MinValue,
+			MaxValue:              o.Mutation.MaxValue,
+		},
+	}
+	for _, d := range o.Directions {
+		m.Directions = append(m.Directions, int32(d))
+	}
+	if o.FixedOutputs {
+		name, ok := activation.Name(o.OutputActivation)
+		if !ok {
+			return nil, errors.New("simulation: the output activation is not registered")
+		}
+		m.OutputActivation = name
+	}
+	return m, nil
+}
+
+func settingsFromProto(m *pb.SimulationOptions) (Settings, error) {
+	mutation := m.GetMutation()
+	o := Settings{
+		PopulationSize:         int(m.GetPopulationSize()),
+		MutationCount:          int(m.GetMutationCount()),
+		Iterations:             int(m.GetIterations()),
+		Threshold:              ThresholdBreak(m.GetThreshold()),
+		ThresholdValue:         m.GetThresholdValue(),
+		Tolerance:              m.GetTolerance(),
+		CrossoverRate:          m.GetCrossoverRate(),
+		Recurrent:              m.GetRecurrent(),
+		generationTimeout:      time.Duration(m.GetGenerationTimeout()),
+		FixedOutputs:           m.GetFixedOutputs(),
+		CompatibilityThreshold: m.GetCompatibilityThreshold(),
+		ExcessCoefficient:      m.GetExcessCoefficient(),
+		DisjointCoefficient:    m.GetDisjointCoefficient(),
+		WeightCoefficient:      m.GetWeightCoefficient(),
+		StagnationLimit:        int(m.GetStagnationLimit()),
+		CheckpointDir:          m.GetCheckpointDir(),
+		CheckpointEvery:        int(m.GetCheckpointEvery()),
+		Elitism:                int(m.GetElitism()),
+		SurvivalFraction:       m.GetSurvivalFraction(),
+		Workers:                int(m.GetWorkers()),
+		AgentTimeout:           time.Duration(m.GetAgentTimeout()),
+		NoveltyNeighbours:      int(m.GetNoveltyNeighbours()),
+		NoveltyWeight:          m.GetNoveltyWeight(),
+		ArchiveThreshold:       m.GetArchiveThreshold(),
+		ArchiveSize:            int(m.GetArchiveSize()),
+		StagnationGenerations:  int(m.GetStagnationGenerations()),
+		StagnationEpsilon:      m.GetStagnationEpsilon(),
+		StagnationPolicy:       StagnationPolicy(m.GetStagnationPolicy()),
+		MutationBoost:          m.GetMutationBoost(),
+		InjectFraction:         m.GetInjectFraction(),
+		HallOfFameSize:         int(m.GetHallOfFameSize()),
+		Mutation: sometinyai.MutationConfig{
+			SplitConnection:       mutation.GetSplitConnection(),
+			AddConnection:         mutation.GetAddConnection(),
+			ChangeWeight:          mutation.GetChangeWeight(),
+			ChangeBias:            mutation.GetChangeBias(),
+			ChangeActivation:      mutation.GetChangeActivation(),
+			RemoveConnection:      mutation.GetRemoveConnection(),
+			RemoveNode:            mutation.GetRemoveNode(),
+			AddConnectionAttempts: int(mutation.GetAddConnectionAttempts()),
+			Sigma:                 mutation.GetSigma(),
+			Replace:               mutation.GetReplace(),
+			MinValue:              mutation.GetMinValue(),
+			MaxValue:              mutation.GetMaxValue(),
+		},
+	}
+	for _, d := range m.GetDirections() {
+		o.Directions = append(o.Directions, Direction(d))
+	}
+	if o.FixedOutputs {
+		a, ok := activation.Lookup(m.GetOutputActivation())
+		if !ok {
+			return Settings{}, fmt.Errorf("simulation: unknown output activation %q", m.GetOutputActivation())
+		}
+		o.OutputActivation = a
+	}
+	return o, nil
+}
+
+func (stats GenerationStats) toProto() *pb.GenerationStats {
+	return &pb.GenerationStats{
+		Generation:      int32(stats.Generation),
+		Best:            stats.Best,
+		Mean:            stats.Mean,
+		Median:          stats.Median,
+		Worst:           stats.Worst,
+		Failed:          int32(stats.Failed),
+		MeanNodes:       stats.MeanNodes,
+		MeanConnections: stats.MeanConnections,
+		MaxNodes:        int32(stats.MaxNodes),
+		MaxConnections:  int32(stats.MaxConnections),
+		Species:         int32(stats.Species),
+		Front:           int32(stats.Front),
+		Stage:           int32(stats.Stage),
+		StageCompleted:  stats.StageCompleted,
+		Stagnated:       stats.Stagnated,
+		Duration:        int64(stats.Duration),
+	}
+}
+
+func statsFromProto(m *pb.GenerationStats) GenerationStats {
+	return GenerationStats{
+		Generation:      int(m.GetGeneration()),
+		Best:            m.GetBest(),
+		Mean:            m.GetMean(),
+		Median:          m.GetMedian(),
+		Worst:           m.GetWorst(),
+		Failed:          int(m.GetFailed()),
+		MeanNodes:       m.GetMeanNodes(),
+		MeanConnections: m.GetMeanConnections(),
+		MaxNodes:        int(m.GetMaxNodes()),
+		MaxConnections:  int(m.GetMaxConnections()),
+		Species:         int(m.GetSpecies()),
+		Front:           int(m.GetFront()),
+		Stage:           int(m.GetStage()),
+		StageCompleted:  m.GetStageCompleted(),
+		Stagnated:       m.GetStagnated(),
+		Duration:        time.Duration(m.GetDuration()),
+	}
+}
@@ -0,0 +1,84 @@
+package simulation
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/matwate/sometinyai"
+	"github.com/matwate/sometinyai/activation"
+)
+
+func xor(g *sometinyai.Genome, _ interface{}) float64 {
+	var fitness float64
+	for _, c := range [][3]float64{{0, 0, 0}, {0, 1, 1}, {1, 0, 1}, {1, 1, 0}} {
+		fitness += 1 - math.Abs(g.ForwardPropagation(c[0], c[1])[0]-c[2])
+	}
+	return fitness
+}
+
+// history leaves out the wall times, which differ between runs.
+func history(h History) History {
+	h = append(History{}, h...)
+	for i := range h {
+		h[i].Duration = 0
+	}
+	return h
+}
+
+func TestCheckpointResume(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{"plain", nil},
+		{"crossover", []Option{CrossoverRate(0.5)}},
+		{"speciation", []Option{Speciation(1), StagnationLimit(3)}},
+		{"stagnation", []Option{Stagnation(2, 1e-3, BoostMutation), HallOfFame(3)}},
+		{"novelty", []Option{Novelty(func(g *sometinyai.Genome, _ interface{}) []float64 {
+			nodes, connections := g.Size()
+			return []float64{float64(nodes), float64(connections)}
+		}, 5)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			opts := append([]Option{
+				PopulationSize(30), Iterations(10), Seed(7), Workers(4),
+				Fitness(xor), Checkpoint(dir, 5),
+			}, tt.opts...)
+			s := NewSimulation(2, 1, activation.Sigmoid, opts...)
+			full, err := s.TrainContext(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := os.Open(filepath.Join(dir, "checkpoint-000005.pb"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			resumed, err := LoadCheckpoint(f, append([]Option{Fitness(xor)}, tt.opts...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resumed.Generation != 5 || len(resumed.History) != 5 {
+				t.Fatalf("resumed at generation %d with %d in the history, want 5",
+					resumed.Generation, len(resumed.History))
+			}
+			res, err := resumed.TrainContext(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := history(res.History), history(full.History); !reflect.DeepEqual(got, want) {
+				t.Errorf("resumed history\n%+v\nwant\n%+v", got, want)
+			}
+			if res.Best.Fitness != full.Best.Fitness {
+				t.Errorf("best fitness %f, want %f", res.Best.Fitness, full.Best.Fitness)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package simulation
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/matwate/sometinyai"
+)
+
+// Stage is a step of a Curriculum. It is completed once the best fitness of
+// MinGenerations generations or more has reached its threshold Patience
+// generations in a row.
+type Stage[D any] struct {
+	Name    string
+	Data    D                                   // Mutable data during the stage
+	Fitness func(*sometinyai.Genome, D) float64 // Nil keeps the simulation's, must be nil with FitnessBatch or Distributed
+
+	Threshold      ThresholdBreak
+	ThresholdValue float64
+	Tolerance      float64 // For Closest, 0.0001 when zero
+
+	MinGenerations int
+	Patience       int  // One when zero
+	Reset          bool // Start the stage from a fresh random population
+}
+
+// Curriculum is an ordered list of stages, usually of increasing difficulty.
+type Curriculum[D any] []Stage[D]
+
+// WithCurriculum makes the simulation go through the stages of c in order,
+// each one setting the mutable data, the fitness function and the threshold
+// while it lasts, and stop once the last one is completed. The generations
+// of every stage are recorded in the history. The success callback still
+// runs, against the threshold of the current stage.
+func WithCurriculum[D any](c Curriculum[D]) TypedOption[D] {
+	return typed("WithCurriculum", func(o *Options[D]) { o.Curriculum = c })
+}
+
+// currentStage returns the stage being run, false without a curriculum or
+// once it is done.
+func (s *Simulation[D]) currentStage() (Stage[D], bool) {
+	if s.stage >= len(s.Config.Curriculum) {
+		return Stage[D]{}, false
+	}
+	return s.Config.Curriculum[s.stage], true
+}
+
+// applyStage sets the options of the current stage.
+func (s *Simulation[D]) applyStage() {
+	stage, ok := s.currentStage()
+	if !ok {
+		return
+	}
+	s.Config.MutableData = stage.Data
+	s.Config.Threshold = stage.Threshold
+	s.Config.ThresholdValue = stage.ThresholdValue
+	s.Config.Tolerance = stage.Tolerance
+	if s.Config.Tolerance == 0 {
+		s.Config.Tolerance = 0.0001
+	}
+}
+
+// startStage enters the current stage, with a fresh population if it asks
+// for one. Scores from the previous stage do not compare with the new ones.
+func (s *Simulation[D]) startStage() {
+	stage, ok := s.currentStage()
+	if !ok {
+		return
+	}
+	s.applyStage()
+	s.bestScore, s.plateauScore, s.plateau = math.Inf(-1), math.Inf(-1), 0
+	if stage.Reset {
+		s.Population = s.fresh(s.Config.PopulationSize)
+		s.Species = nil
+	}
+	s.logger().Info("stage started", "stage", s.stage, "name", stage.Name, "generation", s.Generation)
+}
+
+// progress records a generation of the current stage whose best fitness is
+// the given one, and reports whether that completes the stage.
+func (s *Simulation[D]) progress(fitness float64) bool {
+	stage, ok := s.currentStage()
+	if !ok {
+		return false
+	}
+	s.stageGenerations++
+	if s.reached(fitness) {
+		s.stageStreak++
+	} else {
+		s.stageStreak = 0
+	}
+	return s.stageGenerations >= stage.MinGenerations && s.stageStreak >= max(stage.Patience, 1)
+}
+
+// nextStage moves on to the next stage, and reports whether the curriculum
+// is done.
+func (s *Simulation[D]) nextStage() bool {
+	stage, _ := s.currentStage()
+	s.logger().Info("stage completed", "stage", s.stage, "name", stage.Name,
+		"generation", s.Generation, "generations", s.stageGenerations)
+	s.stage++
+	s.stageGenerations, s.stageStreak = 0, 0
+	return s.stage >= len(s.Config.Curriculum)
+}
+
+// validateCurriculum checks that every stage has a fitness function, of its
+// own or the simulation's, and that its own one would be used: batches and
+// remote workers evaluate with theirs.
+func (s *Simulation[D]) validateCurriculum() error {
+	fitness := s.Config.Fitness != nil || s.Config.FitnessBatch != nil || s.Config.Coordinator != nil ||
+		s.Config.Objectives != nil || s.Config.Behavior != nil
+	for i, stage := range s.Config.Curriculum {
+		switch {
+		case stage.Fitness != nil && (s.Config.FitnessBatch != nil || s.Config.Coordinator != nil):
+			return fmt.Errorf("simulation: stage %d has a fitness function, FitnessBatch and Distributed cannot use it", i)
+		case stage.Fitness == nil && !fitness:
+			return fmt.Errorf("simulation: stage %d has no fitness function", i)
+		}
+	}
+	return nil
+}
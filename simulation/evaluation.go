@@ -0,0 +1,134 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/matwate/sometinyai"
+)
+
+// evaluation is the outcome of evaluating a batch of agents.
+type evaluation struct {
+	agents     []int // Indices in the population
+	fitness    []float64
+	objectives [][]float64 // Only with Objectives
+	behaviors  [][]float64 // Only with Novelty
+	timedOut   bool
+	err        error
+}
+
+// evaluator holds what the workers need from the options, so that they never
+// read the simulation.
+type evaluator[D any] struct {
+	fitness    func(*sometinyai.Genome, D) float64
+	batch      func([]*sometinyai.Genome, D) []float64
+	remote     *Coordinator
+	ctx        context.Context // Cancels remote evaluations
+	objectives func(*sometinyai.Genome, D) []float64
+	count      int // Number of objectives
+	behavior   func(*sometinyai.Genome, D) []float64
+	data       D
+	timeout    time.Duration
+}
+
+func (s *Simulation[D]) evaluator() evaluator[D] {
+	fitness := s.Config.Fitness
+	if stage, ok := s.currentStage(); ok && stage.Fitness != nil {
+		fitness = stage.Fitness
+	}
+	return evaluator[D]{
+		fitness:    fitness,
+		batch:      s.Config.FitnessBatch,
+		remote:     s.Config.Coordinator,
+		ctx:        context.Background(),
+		objectives: s.Config.Objectives,
+		count:      len(s.Config.Directions),
+		behavior:   s.Config.Behavior,
+		data:       s.Config.MutableData,
+		timeout:    s.Config.AgentTimeout,
+	}
+}
+
+// run evaluates the genomes of a batch of agents, with the objectives, the
+// remote workers, the batch fitness or the fitness function, the first one
+// that is set, and describes their behavior for Novelty. A batch
+// that takes longer than the timeout times its size is reported as timed
+// out right away, but run only returns once its evaluation does.
+func (e evaluator[D]) run(agents []int, genomes []*sometinyai.Genome, report func(evaluation)) {
+	if e.timeout <= 0 {
+		report(e.call(agents, genomes))
+		return
+	}
+
+	done := make(chan evaluation, 1)
+	go func() { done <- e.call(agents, genomes) }()
+	timer := time.NewTimer(e.timeout * time.Duration(len(agents)))
+	defer timer.Stop()
+	select {
+	case ev := <-done:
+		report(ev)
+	case <-timer.C:
+		report(evaluation{agents: agents, timedOut: true})
+		<-done
+	}
+}
+
+func (e evaluator[D]) call(agents []int, genomes []*sometinyai.Genome) (ev evaluation) {
+	ev.agents = agents
+	defer func() {
+		if r := recover(); r != nil {
+			ev.err = fmt.Errorf("simulation: fitness of %s panicked: %v", describe(agents), r)
+		}
+	}()
+	switch {
+	case e.objectives != nil:
+		ev.objectives = make([][]float64, len(genomes))
+		for i, g := range genomes {
+			ev.objectives[i] = e.objectives(g, e.data)
+			if len(ev.objectives[i]) != e.count {
+				ev.err = fmt.Errorf("simulation: objectives of agent %d returned %d values, want %d",
+					agents[i], len(ev.objectives[i]), e.count)
+				return ev
+			}
+		}
+		// Only for the statistics and the threshold
+		ev.fitness = make([]float64, len(genomes))
+		for i, g := range genomes {
+			ev.fitness[i] = math.NaN()
+			if e.fitness != nil {
+				ev.fitness[i] = e.fitness(g, e.data)
+			}
+		}
+	case e.remote != nil:
+		ev.fitness, ev.err = e.remote.Evaluate(e.ctx, genomes, e.data)
+	case e.batch != nil:
+		ev.fitness = e.batch(genomes, e.data)
+		if len(ev.fitness) != len(genomes) {
+			ev.err = fmt.Errorf("simulation: batch fitness of %s returned %d values", describe(agents), len(ev.fitness))
+		}
+	default:
+		// Novelty does not need a fitness function
+		ev.fitness = make([]float64, len(genomes))
+		if e.fitness != nil {
+			for i, g := range genomes {
+				ev.fitness[i] = e.fitness(g, e.data)
+			}
+		}
+	}
+	if e.behavior != nil && ev.err == nil {
+		ev.behaviors = make([][]float64, len(genomes))
+		for i, g := range genomes {
+			ev.behaviors[i] = e.behavior(g, e.data)
+		}
+	}
+	return ev
+}
+
+func describe(agents []int) string {
+	if len(agents) == 1 {
+		return fmt.Sprintf("agent %d", agents[0])
+	}
+	return fmt.Sprintf("agents %d to %d", agents[0], agents[len(agents)-1])
+}
@@ -0,0 +1,181 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+
+	"github.com/matwate/sometinyai"
+)
+
+// Topology decides which islands the migrants of every island go to.
+type Topology int
+
+const (
+	Ring           Topology = iota // The next island, the last one sending to the first
+	FullyConnected                 // Every other island
+	Random                         // Another island drawn at every migration
+)
+
+// Islands evolves several populations side by side, each in its own
+// goroutine, so that they explore apart instead of all converging together.
+// Every MigrationInterval generations, copies of the Migrants best agents of
+// every island replace the worst agents of the islands the Topology sends
+// them to. Apart from that the islands are independent simulations, with
+// options, species and innovation trackers of their own.
+type Islands[D any] struct {
+	Islands           []*Simulation[D]
+	Topology          Topology
+	MigrationInterval int
+	Migrants          int
+	rng               *rand.Rand // Draws the destinations with the Random topology
+}
+
+// IslandOption sets how islands migrate, whatever their type.
+type IslandOption func(*migration)
+
+type migration struct {
+	topology        Topology
+	interval, count int
+}
+
+// Migration makes count agents of every island migrate every interval
+// generations, 2 every 10 by default.
+func Migration(interval, count int) IslandOption {
+	return func(m *migration) {
+		m.interval = interval
+		m.count = count
+	}
+}
+
+// MigrationTopology sets where migrants go, Ring by default.
+func MigrationTopology(t Topology) IslandOption {
+	return func(m *migration) { m.topology = t }
+}
+
+// NewIslands makes islands of simulations created with NewSimulation, with
+// the same numbers of inputs and outputs, and that have not been trained
+// yet: their innovation trackers are partitioned so that the markings of the
+// genomes they exchange never collide. Migrants keep being recurrent or not,
+// so islands should agree on Recurrent.
+func NewIslands[D any](islands []*Simulation[D], opts ...IslandOption) *Islands[D] {
+	m := migration{topology: Ring, interval: 10, count: 2}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	i := &Islands[D]{
+		Islands:           islands,
+		Topology:          m.topology,
+		MigrationInterval: m.interval,
+		Migrants:          m.count,
+	}
+
+	for n, s := range islands {
+		s.Innovations.Partition(n, len(islands))
+	}
+	if len(islands) > 0 {
+		i.rng = derive(islands[0].rng)
+	}
+	return i
+}
+
+// Run trains the islands concurrently until each one has run its Iterations,
+// the success callback of one of them asks to stop, or ctx is done. It
+// returns the result of every island, in order, along with the first error.
+func (i *Islands[D]) Run(ctx context.Context) ([]Result[D], error) {
+	results := make([]Result[D], len(i.Islands))
+	for n, s := range i.Islands {
+		if err := s.validate(); err != nil {
+			return results, fmt.Errorf("island %d: %w", n, err)
+		}
+	}
+
+	interval := max(i.MigrationInterval, 1)
+	for {
+		var wg sync.WaitGroup
+		stopped := make([]bool, len(i.Islands))
+		errs := make([]error, len(i.Islands))
+		running := false
+		for n, s := range i.Islands {
+			if s.Generation >= s.Config.Iterations || s.halted {
+				results[n] = s.result()
+				continue
+			}
+			running = true
+			// Islands migrate at the same generations whenever they started
+			until := min((s.Generation/interval+1)*interval, s.Config.Iterations)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[n], stopped[n], errs[n] = s.run(ctx, until)
+			}()
+		}
+		wg.Wait()
+
+		for n, err := range errs {
+			if err != nil {
+				return results, fmt.Errorf("island %d: %w", n, err)
+			}
+		}
+		if !running || slices.Contains(stopped, true) {
+			return results, nil
+		}
+		i.migrate()
+	}
+}
+
+// migrate replaces the worst agents of every island with copies of the best
+// ones of the islands sending to it, as of their last evaluated generation.
+func (i *Islands[D]) migrate() {
+	// Every island picks its migrants before any receives, so that they
+	// only travel one hop
+	incoming := make([]Population, len(i.Islands))
+	for n, s := range i.Islands {
+		migrants := s.ranked[:min(i.Migrants, len(s.ranked))]
+		for _, to := range i.destinations(n) {
+			incoming[to] = append(incoming[to], migrants...)
+		}
+	}
+
+	for n, s := range i.Islands {
+		// The bred population starts with the elites and ends with children,
+		// the first agent is never replaced
+		migrants := incoming[n][:min(len(incoming[n]), max(len(s.Population)-1, 0))]
+		for m, agent := range migrants {
+			g := agent.Genome.Copy(sometinyai.WithInnovationTracker(s.Innovations))
+			g.SetRand(derive(s.rng))
+			s.Population[len(s.Population)-1-m] = Agent{Genome: g}
+		}
+		if len(migrants) > 0 {
+			s.logger().Debug("migration", "island", n, "generation", s.Generation, "migrants", len(migrants))
+		}
+	}
+}
+
+// destinations returns the islands that island n sends its migrants to.
+func (i *Islands[D]) destinations(n int) []int {
+	count := len(i.Islands)
+	if count < 2 {
+		return nil
+	}
+	switch i.Topology {
+	case FullyConnected:
+		var to []int
+		for m := range count {
+			if m != n {
+				to = append(to, m)
+			}
+		}
+		return to
+	case Random:
+		m := i.rng.IntN(count - 1)
+		if m >= n {
+			m++
+		}
+		return []int{m}
+	default:
+		return []int{(n + 1) % count}
+	}
+}
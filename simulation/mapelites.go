@@ -0,0 +1,357 @@
+package simulation
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/matwate/sometinyai"
+	pb "github.com/matwate/sometinyai/protos"
+)
+
+// Dimension is an axis of the MAP-Elites grid, splitting [Min, Max] in Bins
+// equal bins. Descriptors outside of the range fall in the first or last one.
+type Dimension struct {
+	Name     string
+	Min, Max float64
+	Bins     int
+}
+
+// Elite is the best genome found for a cell of the grid.
+type Elite struct {
+	Bins       []int // Bin of every dimension
+	Genome     *sometinyai.Genome
+	Fitness    float64
+	Score      float64 // Fitness turned so that higher is better, see Threshold
+	Descriptor []float64
+}
+
+// Archive is the grid of elites of a MAP-Elites run.
+type Archive struct {
+	Dimensions  []Dimension
+	cells       map[int]Elite // Flattened bins -> elite
+	innovations *sometinyai.InnovationTracker
+}
+
+func newArchive(dimensions []Dimension, innovations *sometinyai.InnovationTracker) *Archive {
+	return &Archive{
+		Dimensions:  dimensions,
+		cells:       map[int]Elite{},
+		innovations: innovations,
+	}
+}
+
+// Size is the number of cells of the grid.
+func (a *Archive) Size() int {
+	size := 1
+	for _, d := range a.Dimensions {
+		size *= d.Bins
+	}
+	return size
+}
+
+// Coverage is the fraction of the cells that hold an elite.
+func (a *Archive) Coverage() float64 {
+	return float64(len(a.cells)) / float64(a.Size())
+}
+
+// QDScore sums how far the score of every elite is above minimum, the
+// lowest score an elite is expected to have, so that filling a cell never
+// lowers it. Elites scoring below minimum count as zero.
+func (a *Archive) QDScore(minimum float64) float64 {
+	var score float64
+	for _, elite := range a.Elites() {
+		score += max(elite.Score-minimum, 0)
+	}
+	return score
+}
+
+// Cell returns the elite of the cell at the given bins, one per dimension.
+func (a *Archive) Cell(bins ...int) (Elite, bool) {
+	cell, ok := a.flatten(bins)
+	if !ok {
+		return Elite{}, false
+	}
+	elite, ok := a.cells[cell]
+	return elite, ok
+}
+
+// Elites returns every elite, ordered by cell.
+func (a *Archive) Elites() []Elite {
+	elites := make([]Elite, 0, len(a.cells))
+	for _, cell := range slices.Sorted(maps.Keys(a.cells)) {
+		elites = append(elites, a.cells[cell])
+	}
+	return elites
+}
+
+// bins returns the bin of the descriptor in every dimension.
+func (a *Archive) bins(descriptor []float64) []int {
+	bins := make([]int, len(a.Dimensions))
+	for i, d := range a.Dimensions {
+		bin := int(math.Floor((descriptor[i] - d.Min) / (d.Max - d.Min) * float64(d.Bins)))
+		bins[i] = min(max(bin, 0), d.Bins-1)
+	}
+	return bins
+}
+
+func (a *Archive) flatten(bins []int) (int, bool) {
+	if len(bins) != len(a.Dimensions) {
+		return 0, false
+	}
+	cell := 0
+	for i, d := range a.Dimensions {
+		if bins[i] < 0 || bins[i] >= d.Bins {
+			return 0, false
+		}
+		cell = cell*d.Bins + bins[i]
+	}
+	return cell, true
+}
+
+// Save writes the archive, with the genome of every elite.
+func (a *Archive) Save(w io.Writer) error {
+	archive := &pb.MapElitesArchive{Innovations: a.innovations.ToProto()}
+	for _, d := range a.Dimensions {
+		archive.Dimensions = append(archive.Dimensions, &pb.Dimension{
+			Name: d.Name,
+			Min:  d.Min,
+			Max:  d.Max,
+			Bins: int32(d.Bins),
+		})
+	}
+	for _, cell := range slices.Sorted(maps.Keys(a.cells)) {
+		elite := a.cells[cell]
+		genome, err := elite.Genome.ToProto()
+		if err != nil {
+			return err
+		}
+		archive.Elites = append(archive.Elites, &pb.Elite{
+			Cell:     int32(cell),
+			Genome:   genome,
+			Fitness:  elite.Fitness,
+			Score:    elite.Score,
+			Features: elite.Descriptor,
+		})
+	}
+
+	out, err := proto.Marshal(archive)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// LoadArchive reads an archive written by Archive.Save.
+func LoadArchive(r io.Reader) (*Archive, error) {
+	in, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	archive := &pb.MapElitesArchive{}
+	if err := proto.Unmarshal(in, archive); err != nil {
+		return nil, err
+	}
+
+	var dimensions []Dimension
+	for _, d := range archive.GetDimensions() {
+		dimension := Dimension{
+			Name: d.GetName(),
+			Min:  d.GetMin(),
+			Max:  d.GetMax(),
+			Bins: int(d.GetBins()),
+		}
+		if dimension.Bins <= 0 || dimension.Max <= dimension.Min {
+			return nil, fmt.Errorf("simulation: dimension %q has no bins", dimension.Name)
+		}
+		dimensions = append(dimensions, dimension)
+	}
+	a := newArchive(dimensions, sometinyai.InnovationTrackerFromProto(archive.GetInnovations()))
+	for _, elite := range archive.GetElites() {
+		g, err := sometinyai.GenomeFromProto(elite.GetGenome(), sometinyai.WithInnovationTracker(a.innovations))
+		if err != nil {
+			return nil, fmt.Errorf("cell %d: %w", elite.GetCell(), err)
+		}
+		descriptor := elite.GetFeatures()
+		if len(descriptor) != len(dimensions) {
+			return nil, fmt.Errorf("cell %d: descriptor has %d values, want %d", elite.GetCell(), len(descriptor), len(dimensions))
+		}
+		bins := a.bins(descriptor)
+		if cell, _ := a.flatten(bins); cell != int(elite.GetCell()) {
+			return nil, fmt.Errorf("cell %d: descriptor falls in cell %d", elite.GetCell(), cell)
+		}
+		if _, ok := a.cells[int(elite.GetCell())]; ok {
+			return nil, fmt.Errorf("cell %d: saved twice", elite.GetCell())
+		}
+		a.cells[int(elite.GetCell())] = Elite{
+			Bins:       bins,
+			Genome:     g,
+			Fitness:    elite.GetFitness(),
+			Score:      elite.GetScore(),
+			Descriptor: descriptor,
+		}
+	}
+	return a, nil
+}
+
+// MapElites fills a grid of elites, one per cell of the descriptor space,
+// instead of evolving a single population towards one champion. Every
+// iteration breeds a batch of PopulationSize children from random elites,
+// and each child takes the cell its descriptor falls in when it is empty or
+// held by a worse genome. Parents are picked uniformly among the elites,
+// unless a Selector is given. The other options of a Simulation apply,
+// except those about ranking and breeding a population.
+type MapElites[D any] struct {
+	Archive *Archive
+	sim     Simulation[D]
+}
+
+// NewMapElites creates a MAP-Elites run where descriptor places every
+// genome in the grid, returning one value per dimension.
+func NewMapElites[D any](
+	inputs, outputs int,
+	act func(float64) float64,
+	descriptor func(*sometinyai.Genome, D) []float64,
+	dimensions []Dimension,
+	opts ...TypedOption[D],
+) *MapElites[D] {
+	sim := NewTypedSimulation[D](inputs, outputs, act, opts...)
+	// Descriptors are evaluated like the behaviors of novelty search
+	sim.Config.Behavior = descriptor
+	if sim.Config.Selector == nil {
+		sim.Config.Selector = Truncation{}
+	}
+	return &MapElites[D]{
+		Archive: newArchive(dimensions, sim.Innovations),
+		sim:     sim,
+	}
+}
+
+// Resume continues from an archive read by LoadArchive instead of the empty
+// one m was created with, before m runs. The archive has to have the same
+// dimensions and genomes with the same inputs and outputs. Its elites are
+// scored again with the Threshold of m, and Run breeds from them rather than
+// from a random population.
+func (m *MapElites[D]) Resume(a *Archive) error {
+	s := &m.sim
+	if s.Generation > 0 {
+		return errors.New("simulation: resuming a MAP-Elites run that already ran")
+	}
+	if !slices.Equal(a.Dimensions, m.Archive.Dimensions) {
+		return errors.New("simulation: archive dimensions do not match")
+	}
+	saved := a.innovations.ToProto()
+	if int(saved.GetInputs()) != s.inputs || int(saved.GetOutputs()) != s.outputs {
+		return fmt.Errorf("simulation: archive genomes have %d inputs and %d outputs, want %d and %d",
+			saved.GetInputs(), saved.GetOutputs(), s.inputs, s.outputs)
+	}
+	for cell, elite := range a.cells {
+		elite.Score = s.score(elite.Fitness)
+		a.cells[cell] = elite
+	}
+	// Children of the elites are marked by the tracker the elites came with
+	s.Innovations = a.innovations
+	m.Archive = a
+	if len(a.cells) > 0 {
+		s.Generation = 1
+	}
+	return nil
+}
+
+// Run evaluates the initial random population and then the given number of
+// iterations, until ctx is done. It returns the archive along with ctx's
+// error when cancelled.
+func (m *MapElites[D]) Run(ctx context.Context) (*Archive, error) {
+	s := &m.sim
+	if err := s.validate(); err != nil {
+		return m.Archive, err
+	}
+	// The descriptor passes for a behavior, the elites still need a fitness
+	if s.Config.Fitness == nil && s.Config.FitnessBatch == nil && s.Config.Coordinator == nil {
+		return m.Archive, errors.New("simulation: no fitness function")
+	}
+	for _, d := range m.Archive.Dimensions {
+		if d.Bins <= 0 || d.Max <= d.Min {
+			return m.Archive, fmt.Errorf("simulation: dimension %q has no bins", d.Name)
+		}
+	}
+
+	for ; s.Generation <= s.Config.Iterations; s.Generation++ {
+		if s.Generation > 0 {
+			s.Population = m.batch()
+		}
+		if err := s.evaluate(ctx); err != nil {
+			return m.Archive, err
+		}
+		if err := m.insert(); err != nil {
+			return m.Archive, err
+		}
+		s.logger().Info("map-elites",
+			"iteration", s.Generation,
+			"coverage", m.Archive.Coverage(),
+			"elites", len(m.Archive.cells),
+		)
+	}
+	return m.Archive, nil
+}
+
+// insert places every evaluated agent of the batch in its cell, if it
+// beats the elite there.
+func (m *MapElites[D]) insert() error {
+	s := &m.sim
+	for i, agent := range s.Population {
+		if agent.Behavior == nil || math.IsInf(s.score(agent.Fitness), 0) {
+			continue
+		}
+		if len(agent.Behavior) != len(m.Archive.Dimensions) {
+			return fmt.Errorf("simulation: descriptor of agent %d has %d values, want %d",
+				i, len(agent.Behavior), len(m.Archive.Dimensions))
+		}
+		bins := m.Archive.bins(agent.Behavior)
+		cell, _ := m.Archive.flatten(bins)
+		score := s.score(agent.Fitness)
+		if elite, ok := m.Archive.cells[cell]; ok && elite.Score >= score {
+			continue
+		}
+		m.Archive.cells[cell] = Elite{
+			Bins:       bins,
+			Genome:     agent.Genome,
+			Fitness:    agent.Fitness,
+			Score:      score,
+			Descriptor: agent.Behavior,
+		}
+	}
+	return nil
+}
+
+// batch breeds the next batch of children from the elites.
+func (m *MapElites[D]) batch() Population {
+	s := &m.sim
+	elites := m.Archive.Elites()
+	if len(elites) == 0 {
+		// Nothing could be evaluated yet, start over
+		return s.fresh(s.Config.PopulationSize)
+	}
+	parents := make(Population, len(elites))
+	for i, elite := range elites {
+		parents[i] = Agent{Genome: elite.Genome, Fitness: elite.Fitness}
+	}
+	// Sorted best first, as breeding expects
+	slices.SortStableFunc(parents, func(a, b Agent) int {
+		return cmp.Compare(s.score(b.Fitness), s.score(a.Fitness))
+	})
+
+	batch := make(Population, s.Config.PopulationSize)
+	for i, child := range s.children(parents, len(batch)) {
+		batch[i].Genome = child
+	}
+	return batch
+}
@@ -0,0 +1,127 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/matwate/sometinyai"
+	"github.com/matwate/sometinyai/activation"
+	pb "github.com/matwate/sometinyai/protos"
+)
+
+func size(g *sometinyai.Genome, _ interface{}) []float64 {
+	nodes, connections := g.Size()
+	return []float64{float64(nodes), float64(connections)}
+}
+
+var sizeDimensions = []Dimension{{Name: "nodes", Min: 3, Max: 13, Bins: 10}, {Name: "connections", Max: 20, Bins: 10}}
+
+func testArchive(t *testing.T) []byte {
+	t.Helper()
+	m := NewMapElites(2, 1, activation.Sigmoid, size, sizeDimensions,
+		Fitness(xor), PopulationSize(20), Iterations(10), Seed(1))
+	archive, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := archive.Save(&b); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestLoadArchive(t *testing.T) {
+	saved := testArchive(t)
+	tests := []struct {
+		name   string
+		modify func(*pb.MapElitesArchive)
+		err    string
+	}{
+		{"unchanged", func(*pb.MapElitesArchive) {}, ""},
+		{"wrong cell", func(a *pb.MapElitesArchive) { a.Elites[0].Cell++ }, "falls in cell"},
+		{"cell out of the grid", func(a *pb.MapElitesArchive) { a.Elites[0].Cell = 1000 }, "falls in cell"},
+		{"same cell twice", func(a *pb.MapElitesArchive) { a.Elites = append(a.Elites, a.Elites[0]) }, "twice"},
+		{"no bins", func(a *pb.MapElitesArchive) { a.Dimensions[1].Bins = 0 }, "no bins"},
+		{"short descriptor", func(a *pb.MapElitesArchive) { a.Elites[0].Features = a.Elites[0].Features[:1] }, "descriptor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := &pb.MapElitesArchive{}
+			if err := proto.Unmarshal(saved, archive); err != nil {
+				t.Fatal(err)
+			}
+			tt.modify(archive)
+			in, err := proto.Marshal(archive)
+			if err != nil {
+				t.Fatal(err)
+			}
+			a, err := LoadArchive(bytes.NewReader(in))
+			if tt.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := len(a.Elites()); got != len(archive.GetElites()) {
+					t.Errorf("loaded %d elites, want %d", got, len(archive.GetElites()))
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("got error %v, want one about %s", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestMapElitesResume(t *testing.T) {
+	saved := testArchive(t)
+	load := func() *Archive {
+		a, err := LoadArchive(bytes.NewReader(saved))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return a
+	}
+	before := load()
+
+	m := NewMapElites(2, 1, activation.Sigmoid, size, sizeDimensions,
+		Fitness(xor), PopulationSize(20), Iterations(10), Seed(2))
+	if err := m.Resume(load()); err != nil {
+		t.Fatal(err)
+	}
+	after, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Elites are only ever replaced by better ones
+	for _, elite := range before.Elites() {
+		resumed, ok := after.Cell(elite.Bins...)
+		if !ok || resumed.Score < elite.Score {
+			t.Errorf("cell %v: lost elite scoring %f", elite.Bins, elite.Score)
+		}
+	}
+	if after.Coverage() < before.Coverage() {
+		t.Errorf("coverage went from %f down to %f", before.Coverage(), after.Coverage())
+	}
+
+	tests := []struct {
+		name string
+		m    *MapElites[interface{}]
+		err  string
+	}{
+		{"other dimensions", NewMapElites(2, 1, activation.Sigmoid, size, sizeDimensions[:1], Fitness(xor)), "dimensions"},
+		{"other inputs", NewMapElites(3, 1, activation.Sigmoid, size, sizeDimensions, Fitness(xor)), "inputs"},
+		{"already ran", m, "already ran"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.m.Resume(load()); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("got error %v, want one about %s", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package simulation
+
+import (
+	"math"
+	"slices"
+
+	"github.com/matwate/sometinyai"
+)
+
+// Novelty ranks the agents by how different their behavior is, instead of
+// by their fitness, which gets past deceptive fitness functions. behavior
+// describes what an agent did as a vector, and the novelty of an agent is
+// its mean euclidean distance to the k nearest behaviors among the
+// population and the archive of past novel behaviors. The fitness function
+// is optional, it is still evaluated, reported and checked against the
+// threshold, see NoveltyBlend to also rank by it.
+func Novelty[D any](behavior func(*sometinyai.Genome, D) []float64, k int) TypedOption[D] {
+	return typed("Novelty", func(o *Options[D]) {
+		o.Behavior = behavior
+		o.NoveltyNeighbours = k
+	})
+}
+
+// NoveltyBlend ranks the agents by a weighted blend of their novelty and
+// their fitness, both scaled to the range of the generation. A weight of 1,
+// the default, is pure novelty and 0 pure fitness.
+func NoveltyBlend(weight float64) Option {
+	return func(o *Settings) { o.NoveltyWeight = weight }
+}
+
+// NoveltyArchive sets which behaviors are archived every generation: those
+// with a novelty of at least threshold, or only the most novel one while
+// threshold is zero. Once the archive holds size behaviors the oldest ones
+// are dropped, it grows unbounded while size is zero.
+func NoveltyArchive(threshold float64, size int) Option {
+	return func(o *Settings) {
+		o.ArchiveThreshold = threshold
+		o.ArchiveSize = size
+	}
+}
+
+// rankNovelty computes the novelty of every evaluated agent against the
+// population and the archive, blends it with the fitness into the agent's
+// score, and archives the most novel behaviors.
+func (s *Simulation[D]) rankNovelty() {
+	k := s.Config.NoveltyNeighbours
+	if k <= 0 {
+		k = 15
+	}
+
+	noveltyMin, noveltyMax := math.Inf(1), math.Inf(-1)
+	fitnessMin, fitnessMax := math.Inf(1), math.Inf(-1)
+	distances := make([]float64, 0, len(s.Population)+len(s.archive))
+	for i, agent := range s.Population {
+		s.Population[i].Novelty = 0
+		if agent.Behavior == nil {
+			continue
+		}
+		distances = distances[:0]
+		for j, other := range s.Population {
+			if i != j && other.Behavior != nil {
+				distances = append(distances, euclidean(agent.Behavior, other.Behavior))
+			}
+		}
+		for _, behavior := range s.archive {
+			distances = append(distances, euclidean(agent.Behavior, behavior))
+		}
+		slices.Sort(distances)
+		nearest := distances[:min(k, len(distances))]
+		var novelty float64
+		for _, d := range nearest {
+			novelty += d / float64(len(nearest))
+		}
+		s.Population[i].Novelty = novelty
+
+		noveltyMin, noveltyMax = min(noveltyMin, novelty), max(noveltyMax, novelty)
+		if score := s.score(agent.Fitness); !math.IsInf(score, 0) {
+			fitnessMin, fitnessMax = min(fitnessMin, score), max(fitnessMax, score)
+		}
+	}
+
+	scale := func(v, lo, hi float64) float64 {
+		if hi <= lo {
+			return 0
+		}
+		return (v - lo) / (hi - lo)
+	}
+	w := s.Config.NoveltyWeight
+	for i, agent := range s.Population {
+		fitness := s.score(agent.Fitness)
+		if agent.Behavior == nil || (math.IsInf(fitness, 0) && w < 1) {
+			s.Population[i].Score = math.Inf(-1)
+			continue
+		}
+		s.Population[i].Score = w * scale(agent.Novelty, noveltyMin, noveltyMax)
+		if w < 1 {
+			s.Population[i].Score += (1 - w) * scale(fitness, fitnessMin, fitnessMax)
+		}
+	}
+
+	s.archiveNovel()
+}
+
+// archiveNovel adds the behaviors of the generation that are novel enough
+// to the archive, and drops the oldest ones past its size.
+func (s *Simulation[D]) archiveNovel() {
+	if s.Config.ArchiveThreshold > 0 {
+		for _, agent := range s.Population {
+			if agent.Behavior != nil && agent.Novelty >= s.Config.ArchiveThreshold {
+				s.archive = append(s.archive, agent.Behavior)
+			}
+		}
+	} else {
+		best := -1
+		for i, agent := range s.Population {
+			if agent.Behavior != nil && (best < 0 || agent.Novelty > s.Population[best].Novelty) {
+				best = i
+			}
+		}
+		if best >= 0 {
+			s.archive = append(s.archive, s.Population[best].Behavior)
+		}
+	}
+	if size := s.Config.ArchiveSize; size > 0 && len(s.archive) > size {
+		s.archive = slices.Delete(s.archive, 0, len(s.archive)-size)
+	}
+}
+
+func euclidean(a, b []float64) float64 {
+	var sum float64
+	for i := range min(len(a), len(b)) {
+		sum += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Sqrt(sum)
+}
+
+// rankScore is the value agents are sorted and selected by, higher being
+// better: their score with Novelty or Objectives, and their fitness otherwise.
+func (s *Simulation[D]) rankScore(a Agent) float64 {
+	if s.Config.Behavior != nil || s.Config.Objectives != nil {
+		return a.Score
+	}
+	return s.score(a.Fitness)
+}
+
+// fittest returns the agent with the best fitness, the first one of the
+// sorted population unless it is ranked by score. Without fitness, as with
+// Objectives alone, that is the first one.
+func (s *Simulation[D]) fittest() Agent {
+	best := s.Population[0]
+	for _, agent := range s.Population[1:] {
+		if s.score(agent.Fitness) > s.score(best.Fitness) {
+			best = agent
+		}
+	}
+	return best
+}
@@ -0,0 +1,167 @@
+package simulation
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/matwate/sometinyai"
+)
+
+// Direction tells whether an objective is to be maximized or minimized.
+type Direction int
+
+const (
+	Maximize Direction = iota
+	Minimize
+)
+
+// Objectives evolves the population on several objectives at once, with
+// NSGA-II: f returns one value per direction, agents are ranked by the
+// Pareto front they belong to and, within a front, by their crowding
+// distance, which sets their Score. Their Fitness is NaN, unless a fitness
+// function is given too: it is then evaluated for the statistics, the
+// threshold and the success callback, but not used for ranking. The non
+// dominated agents are in Result.Front.
+//
+// Unlike NSGA-II, parents and children are not pooled before keeping the
+// best half: every generation is bred from the ranked population like
+// without objectives, only Elitism carrying agents over. f is evaluated in
+// the simulation's own workers, so Objectives cannot be combined with
+// FitnessBatch or Distributed.
+func Objectives[D any](f func(*sometinyai.Genome, D) []float64, directions ...Direction) TypedOption[D] {
+	return typed("Objectives", func(o *Options[D]) {
+		o.Objectives = f
+		o.Directions = directions
+	})
+}
+
+// rankObjectives sorts the evaluated agents into Pareto fronts, computes
+// their crowding distances and turns both into their score. It returns the
+// first front.
+func (s *Simulation[D]) rankObjectives() Population {
+	// Every objective is turned into one where higher is better
+	oriented := make([][]float64, len(s.Population))
+	for i, agent := range s.Population {
+		s.Population[i].Rank, s.Population[i].Crowding = -1, 0
+		s.Population[i].Score = math.Inf(-1)
+		if agent.Objectives == nil {
+			continue
+		}
+		oriented[i] = make([]float64, len(agent.Objectives))
+		for j, v := range agent.Objectives {
+			if s.Config.Directions[j] == Minimize {
+				v = -v
+			}
+			oriented[i][j] = v
+		}
+	}
+
+	fronts := nondominated(oriented)
+	for rank, front := range fronts {
+		for j, crowding := range crowdingDistances(oriented, front) {
+			agent := &s.Population[front[j]]
+			agent.Rank, agent.Crowding = rank, crowding
+			// Every front scores below the previous one whatever the crowding
+			if math.IsInf(crowding, 1) {
+				agent.Score = float64(-rank) + 1
+			} else {
+				agent.Score = float64(-rank) + 0.5 + 0.5*crowding/(1+crowding)
+			}
+		}
+	}
+
+	var front Population
+	if len(fronts) > 0 {
+		for _, i := range fronts[0] {
+			front = append(front, s.Population[i])
+		}
+	}
+	return front
+}
+
+// dominates reports whether a is at least as good as b on every objective,
+// and better on one.
+func dominates(a, b []float64) bool {
+	better := false
+	for i := range a {
+		if a[i] < b[i] {
+			return false
+		}
+		if a[i] > b[i] {
+			better = true
+		}
+	}
+	return better
+}
+
+// nondominated is the fast non dominated sort of NSGA-II. It returns the
+// indices of the points of every front, best first, leaving out nil points.
+func nondominated(points [][]float64) [][]int {
+	dominated := make([][]int, len(points)) // Points each one dominates
+	counts := make([]int, len(points))      // Points dominating each one
+	var front []int
+	for i := range points {
+		if points[i] == nil {
+			continue
+		}
+		for j := range points {
+			if i == j || points[j] == nil {
+				continue
+			}
+			if dominates(points[i], points[j]) {
+				dominated[i] = append(dominated[i], j)
+			} else if dominates(points[j], points[i]) {
+				counts[i]++
+			}
+		}
+		if counts[i] == 0 {
+			front = append(front, i)
+		}
+	}
+
+	var fronts [][]int
+	for len(front) > 0 {
+		fronts = append(fronts, front)
+		var next []int
+		for _, i := range front {
+			for _, j := range dominated[i] {
+				counts[j]--
+				if counts[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		front = next
+	}
+	return fronts
+}
+
+// crowdingDistances returns the crowding distance of every point of the
+// front: the sum over the objectives of the normalized distance between its
+// neighbours. The extremes of every objective are infinitely far.
+func crowdingDistances(points [][]float64, front []int) []float64 {
+	distances := make([]float64, len(front))
+	if len(front) == 0 {
+		return distances
+	}
+	order := make([]int, len(front)) // Positions in front
+	for objective := range points[front[0]] {
+		for i := range order {
+			order[i] = i
+		}
+		value := func(i int) float64 { return points[front[i]][objective] }
+		slices.SortStableFunc(order, func(a, b int) int { return cmp.Compare(value(a), value(b)) })
+
+		lowest, highest := value(order[0]), value(order[len(order)-1])
+		distances[order[0]] = math.Inf(1)
+		distances[order[len(order)-1]] = math.Inf(1)
+		if highest == lowest {
+			continue
+		}
+		for k := 1; k < len(order)-1; k++ {
+			distances[order[k]] += (value(order[k+1]) - value(order[k-1])) / (highest - lowest)
+		}
+	}
+	return distances
+}
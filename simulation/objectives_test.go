@@ -0,0 +1,142 @@
+package simulation
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/matwate/sometinyai"
+	"github.com/matwate/sometinyai/activation"
+)
+
+func TestNondominated(t *testing.T) {
+	tests := []struct {
+		name   string
+		points [][]float64
+		want   [][]int
+	}{
+		{"empty", nil, nil},
+		{"single", [][]float64{{1, 1}}, [][]int{{0}}},
+		{
+			name:   "trade-off",
+			points: [][]float64{{1, 3}, {2, 2}, {3, 1}},
+			want:   [][]int{{0, 1, 2}},
+		},
+		{
+			name:   "chain",
+			points: [][]float64{{1, 1}, {3, 3}, {2, 2}},
+			want:   [][]int{{1}, {2}, {0}},
+		},
+		{
+			name:   "fronts",
+			points: [][]float64{{4, 1}, {1, 4}, {3, 3}, {2, 2}, {1, 1}, {0, 3}},
+			want:   [][]int{{0, 1, 2}, {3, 5}, {4}},
+		},
+		{
+			name:   "equal points",
+			points: [][]float64{{1, 1}, {1, 1}, {0, 0}},
+			want:   [][]int{{0, 1}, {2}},
+		},
+		{
+			name:   "failed agents",
+			points: [][]float64{nil, {1, 1}, nil, {2, 0}},
+			want:   [][]int{{1, 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nondominated(tt.points); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got fronts %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCrowdingDistances(t *testing.T) {
+	inf := math.Inf(1)
+	tests := []struct {
+		name   string
+		points [][]float64
+		front  []int
+		want   []float64
+	}{
+		{"empty", [][]float64{{1, 1}}, nil, []float64{}},
+		{"single", [][]float64{{1, 1}}, []int{0}, []float64{inf}},
+		{"pair", [][]float64{{1, 2}, {2, 1}}, []int{0, 1}, []float64{inf, inf}},
+		{
+			// The middle one spans the whole range of both objectives
+			name:   "line",
+			points: [][]float64{{0, 4}, {2, 2}, {4, 0}},
+			front:  []int{0, 1, 2},
+			want:   []float64{inf, 2, inf},
+		},
+		{
+			name:   "uneven",
+			points: [][]float64{{0, 4}, {1, 3}, {3, 1}, {4, 0}},
+			front:  []int{0, 1, 2, 3},
+			want:   []float64{inf, 1.5, 1.5, inf},
+		},
+		{
+			name:   "part of the points",
+			points: [][]float64{{0, 4}, {9, 9}, {2, 2}, {4, 0}},
+			front:  []int{3, 2, 0},
+			want:   []float64{inf, 2, inf},
+		},
+		{
+			// No spread on the second objective, only the first one counts
+			name:   "flat objective",
+			points: [][]float64{{0, 1}, {1, 1}, {4, 1}},
+			front:  []int{0, 1, 2},
+			want:   []float64{inf, 1, inf},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := crowdingDistances(tt.points, tt.front)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] && math.Abs(got[i]-tt.want[i]) > 1e-9 {
+					t.Errorf("got %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestObjectivesOptions(t *testing.T) {
+	objectives := Objectives(func(g *sometinyai.Genome, data interface{}) []float64 {
+		return []float64{xor(g, data)}
+	}, Maximize)
+	batch := FitnessBatch(func(genomes []*sometinyai.Genome, _ interface{}) []float64 {
+		return make([]float64, len(genomes))
+	})
+	tests := []struct {
+		name string
+		opts []Option
+		err  string
+	}{
+		{"alone", []Option{objectives}, ""},
+		{"with fitness", []Option{objectives, Fitness(xor)}, ""},
+		{"no direction", []Option{Objectives(func(*sometinyai.Genome, interface{}) []float64 { return nil })}, "direction"},
+		{"batch", []Option{objectives, batch}, "FitnessBatch"},
+		{"distributed", []Option{objectives, Distributed(NewCoordinator())}, "Distributed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{PopulationSize(10), Iterations(2)}, tt.opts...)
+			s := NewSimulation(2, 1, activation.Sigmoid, opts...)
+			_, err := s.TrainContext(context.Background())
+			if tt.err == "" && err != nil {
+				t.Fatal(err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Fatalf("got error %v, want one about %s", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package simulation
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// Observer is notified as training progresses. Embed NopObserver to only
+// implement the methods of interest.
+type Observer interface {
+	OnGenerationStart(generation int)
+	OnGenerationEnd(stats GenerationStats)
+	// OnImprovement is called when a generation beats the best fitness of
+	// every previous one.
+	OnImprovement(generation int, best Agent)
+	// OnSpeciation is called after every generation has been split into
+	// species, only with Speciation.
+	OnSpeciation(generation int, species []SpeciesStats)
+}
+
+// NopObserver implements Observer doing nothing.
+type NopObserver struct{}
+
+func (NopObserver) OnGenerationStart(int)            {}
+func (NopObserver) OnGenerationEnd(GenerationStats)  {}
+func (NopObserver) OnImprovement(int, Agent)         {}
+func (NopObserver) OnSpeciation(int, []SpeciesStats) {}
+
+// GenerationStats summarizes an evaluated generation. Fitness statistics
+// leave out the agents that failed, see Agent.Failed, and the ones without
+// fitness, see Objectives.
+type GenerationStats struct {
+	Generation                 int
+	Best, Mean, Median, Worst  float64
+	Failed                     int
+	MeanNodes, MeanConnections float64
+	MaxNodes, MaxConnections   int
+	Species                    int
+	Front                      int           // Size of the Pareto front, only with Objectives
+	Stage                      int           // Curriculum stage, see WithCurriculum
+	StageCompleted             bool          // The generation completed its stage
+	Stagnated                  bool          // The stagnation policy was applied, see Stagnation
+	Duration                   time.Duration // Wall time of evaluation and breeding
+}
+
+// SpeciesStats summarizes a species of a generation.
+type SpeciesStats struct {
+	ID          int
+	Size        int
+	BestFitness float64
+	Stagnation  int
+	Offspring   int
+}
+
+// History holds the statistics of every generation run so far.
+type History []GenerationStats
+
+// WithObserver adds an observer to the training.
+func WithObserver(o Observer) Option {
+	return func(opts *Settings) { opts.Observers = append(opts.Observers, o) }
+}
+
+// stats summarizes the sorted, evaluated population.
+func (s *Simulation[D]) stats() GenerationStats {
+	stats := GenerationStats{Generation: s.Generation}
+
+	var fitness []float64
+	var nodes, connections int
+	for _, agent := range s.Population {
+		if agent.Failed {
+			stats.Failed++
+		} else if !math.IsNaN(agent.Fitness) {
+			fitness = append(fitness, agent.Fitness)
+		}
+		n, c := agent.Genome.Size()
+		nodes += n
+		connections += c
+		stats.MaxNodes = max(stats.MaxNodes, n)
+		stats.MaxConnections = max(stats.MaxConnections, c)
+	}
+	stats.MeanNodes = float64(nodes) / float64(len(s.Population))
+	stats.MeanConnections = float64(connections) / float64(len(s.Population))
+
+	if len(fitness) > 0 {
+		// The population is not sorted by fitness with Novelty or Objectives
+		stats.Best, stats.Worst = fitness[0], fitness[0]
+		for _, f := range fitness {
+			if s.score(f) > s.score(stats.Best) {
+				stats.Best = f
+			}
+			if s.score(f) < s.score(stats.Worst) {
+				stats.Worst = f
+			}
+		}
+		for _, f := range fitness {
+			stats.Mean += f
+		}
+		stats.Mean /= float64(len(fitness))
+		slices.Sort(fitness)
+		if n := len(fitness); n%2 == 1 {
+			stats.Median = fitness[n/2]
+		} else {
+			stats.Median = (fitness[n/2-1] + fitness[n/2]) / 2
+		}
+	}
+	return stats
+}
+
+func (s *Simulation[D]) speciesStats() []SpeciesStats {
+	stats := make([]SpeciesStats, len(s.Species))
+	for i, sp := range s.Species {
+		stats[i] = SpeciesStats{
+			ID:          sp.ID,
+			Size:        len(sp.Members),
+			BestFitness: sp.Members[0].Fitness,
+			Stagnation:  sp.Stagnation,
+			Offspring:   sp.Offspring,
+		}
+	}
+	return stats
+}
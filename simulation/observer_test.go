@@ -0,0 +1,61 @@
+package simulation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/matwate/sometinyai"
+	"github.com/matwate/sometinyai/activation"
+)
+
+func TestStats(t *testing.T) {
+	failed := Agent{Fitness: math.Inf(-1), Failed: true}
+	tests := []struct {
+		name   string
+		agents []Agent
+		want   GenerationStats
+	}{
+		{
+			name:   "odd",
+			agents: []Agent{{Fitness: 3}, {Fitness: 1}, {Fitness: 2}},
+			want:   GenerationStats{Best: 3, Mean: 2, Median: 2, Worst: 1},
+		},
+		{
+			name:   "even",
+			agents: []Agent{{Fitness: 4}, {Fitness: 1}, {Fitness: 2}, {Fitness: 3}},
+			want:   GenerationStats{Best: 4, Mean: 2.5, Median: 2.5, Worst: 1},
+		},
+		{
+			name:   "failed",
+			agents: []Agent{{Fitness: 2}, failed, {Fitness: 1}, failed},
+			want:   GenerationStats{Best: 2, Mean: 1.5, Median: 1.5, Worst: 1, Failed: 2},
+		},
+		{
+			// A real infinite fitness is not a failure
+			name:   "infinite fitness",
+			agents: []Agent{{Fitness: math.Inf(-1)}, {Fitness: 1}, {Fitness: 3}},
+			want:   GenerationStats{Best: 3, Mean: math.Inf(-1), Median: 1, Worst: math.Inf(-1)},
+		},
+		{
+			name:   "no fitness",
+			agents: []Agent{{Fitness: math.NaN()}, {Fitness: 1}},
+			want:   GenerationStats{Best: 1, Mean: 1, Median: 1, Worst: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSimulation(2, 1, activation.Sigmoid)
+			s.Population = tt.agents
+			for i := range s.Population {
+				s.Population[i].Genome = sometinyai.NewGenome(2, 1, activation.Sigmoid)
+			}
+			got := s.stats()
+			if got.Best != tt.want.Best || got.Mean != tt.want.Mean || got.Median != tt.want.Median ||
+				got.Worst != tt.want.Worst || got.Failed != tt.want.Failed {
+				t.Errorf("got best %v, mean %v, median %v, worst %v, failed %d, want %v, %v, %v, %v, %d",
+					got.Best, got.Mean, got.Median, got.Worst, got.Failed,
+					tt.want.Best, tt.want.Mean, tt.want.Median, tt.want.Worst, tt.want.Failed)
+			}
+		})
+	}
+}
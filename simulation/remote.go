@@ -0,0 +1,464 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"reflect"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/matwate/sometinyai"
+	pb "github.com/matwate/sometinyai/protos"
+)
+
+// Distributed makes the population be evaluated by the workers registered
+// with c, instead of by the fitness function. Every batch, the whole
+// population unless Workers splits it, is shared out between them.
+func Distributed(c *Coordinator) Option {
+	return func(o *Settings) { o.Coordinator = c }
+}
+
+// Coordinator ships genomes to remote workers, as EvaluationRequest
+// messages, and collects their fitness. A worker that fails, or that does
+// not answer within the request timeout, has its genomes sent to the next
+// worker instead, up to the given number of retries.
+type Coordinator struct {
+	Timeout time.Duration // Of every request, none while zero
+	Retries int
+	Encode  func(interface{}) ([]byte, error)          // Sends the mutable data along, none without it
+	Client  *http.Client                               // Sends the requests, http.DefaultClient while nil
+	Accept  func(r *http.Request, address string) bool // Filters the registrations of ServeHTTP, none pass while nil
+
+	mu        sync.Mutex
+	workers   []worker
+	loopbacks int
+}
+
+type CoordinatorOption func(*Coordinator)
+
+// RequestTimeout gives every worker at most d to answer a request, none by
+// default.
+func RequestTimeout(d time.Duration) CoordinatorOption {
+	return func(c *Coordinator) { c.Timeout = d }
+}
+
+// Retries sets how many other workers a request is sent to when a worker
+// fails, 2 by default.
+func Retries(n int) CoordinatorOption {
+	return func(c *Coordinator) { c.Retries = n }
+}
+
+// EncodeData makes the coordinator send the mutable data to the workers,
+// encoded by f, see Worker.Decode.
+func EncodeData[D any](f func(D) ([]byte, error)) CoordinatorOption {
+	return func(c *Coordinator) {
+		c.Encode = func(data interface{}) ([]byte, error) {
+			d, ok := data.(D)
+			if !ok && data != nil {
+				return nil, fmt.Errorf("simulation: EncodeData takes mutable data of type %v, not %T", reflect.TypeFor[D](), data)
+			}
+			return f(d)
+		}
+	}
+}
+
+// AcceptWorkers makes ServeHTTP register the workers f accepts, given the
+// registration request and the worker's URL. It rejects them all without it.
+func AcceptWorkers(f func(r *http.Request, address string) bool) CoordinatorOption {
+	return func(c *Coordinator) { c.Accept = f }
+}
+
+// HTTPClient makes the coordinator send its requests with client, to set
+// up TLS, proxies or authentication.
+func HTTPClient(client *http.Client) CoordinatorOption {
+	return func(c *Coordinator) { c.Client = client }
+}
+
+func NewCoordinator(opts ...CoordinatorOption) *Coordinator {
+	c := &Coordinator{
+		Retries: 2,
+		Client:  http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Coordinator) client() *http.Client {
+	if c.Client == nil {
+		return http.DefaultClient
+	}
+	return c.Client
+}
+
+// worker is where a coordinator sends its requests.
+type worker struct {
+	address  string
+	evaluate func(context.Context, *pb.EvaluationRequest) (*pb.EvaluationResponse, error)
+}
+
+// Register adds the worker serving at the given URL. Registering it again
+// does nothing.
+func (c *Coordinator) Register(address string) {
+	c.add(worker{address: address, evaluate: func(ctx context.Context, req *pb.EvaluationRequest) (*pb.EvaluationResponse, error) {
+		return exchange(ctx, address, req, c.client().Do)
+	}})
+}
+
+// RegisterLoopback adds a worker served by h, usually a Worker, in the same
+// process: it gets the genomes serialized as remote ones do, but without a
+// network. It is meant for tests.
+func (c *Coordinator) RegisterLoopback(h http.Handler) {
+	c.mu.Lock()
+	address := fmt.Sprintf("loopback-%d", c.loopbacks)
+	c.loopbacks++
+	c.mu.Unlock()
+	c.add(worker{address: address, evaluate: func(ctx context.Context, req *pb.EvaluationRequest) (*pb.EvaluationResponse, error) {
+		return exchange(ctx, "/", req, func(r *http.Request) (*http.Response, error) { return serve(h, r) })
+	}})
+}
+
+func (c *Coordinator) add(w worker) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !slices.ContainsFunc(c.workers, func(o worker) bool { return o.address == w.address }) {
+		c.workers = append(c.workers, w)
+	}
+}
+
+// Unregister removes the worker serving at the given URL.
+func (c *Coordinator) Unregister(address string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.workers = slices.DeleteFunc(c.workers, func(w worker) bool { return w.address == address })
+}
+
+// Workers returns the URLs of the registered workers.
+func (c *Coordinator) Workers() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	addresses := make([]string, len(c.workers))
+	for i, w := range c.workers {
+		addresses[i] = w.address
+	}
+	return addresses
+}
+
+// ServeHTTP lets workers register themselves with a WorkerRegistration
+// message, sent with POST to register and DELETE to unregister, see
+// RegisterWorker. The coordinator sends genomes and mutable data to any URL
+// registered this way, so every registration is rejected unless AcceptWorkers
+// filters them, even when only trusted workers can reach the endpoint.
+func (c *Coordinator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	in, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	registration := &pb.WorkerRegistration{}
+	if err := proto.Unmarshal(in, registration); err != nil || registration.GetAddress() == "" {
+		http.Error(w, "invalid registration", http.StatusBadRequest)
+		return
+	}
+	if c.Accept == nil || !c.Accept(r, registration.GetAddress()) {
+		http.Error(w, "worker not accepted", http.StatusForbidden)
+		return
+	}
+	switch r.Method {
+	case http.MethodPost:
+		c.Register(registration.GetAddress())
+	case http.MethodDelete:
+		c.Unregister(registration.GetAddress())
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sometinyai.Logger().Debug("worker registration", "method", r.Method, "worker", registration.GetAddress())
+}
+
+// RegisterWorker registers the worker serving at address with the
+// coordinator serving at the given URL, sending the request with client, or
+// http.DefaultClient when nil.
+func RegisterWorker(ctx context.Context, client *http.Client, coordinator, address string) error {
+	body, err := proto.Marshal(&pb.WorkerRegistration{Address: address})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, coordinator, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("simulation: registering with %s: %s", coordinator, res.Status)
+	}
+	return nil
+}
+
+// Evaluate returns the fitness of the genomes, computed by the registered
+// workers, each one getting a contiguous share of them. Every genome is sent
+// along with a seed drawn from its random source.
+func (c *Coordinator) Evaluate(ctx context.Context, genomes []*sometinyai.Genome, data interface{}) ([]float64, error) {
+	workers := c.snapshot()
+	if len(workers) == 0 {
+		return nil, errors.New("simulation: no worker registered")
+	}
+	var payload []byte
+	if c.Encode != nil {
+		var err error
+		if payload, err = c.Encode(data); err != nil {
+			return nil, err
+		}
+	}
+
+	n := min(len(workers), len(genomes))
+	size := (len(genomes) + n - 1) / max(n, 1)
+	var requests []*pb.EvaluationRequest
+	for start := 0; start < len(genomes); start += size {
+		req := &pb.EvaluationRequest{Data: payload}
+		for _, g := range genomes[start:min(start+size, len(genomes))] {
+			m, err := g.ToProto()
+			if err != nil {
+				return nil, err
+			}
+			req.Genomes = append(req.Genomes, m)
+			req.Seeds = append(req.Seeds, g.Rand().Uint64())
+		}
+		requests = append(requests, req)
+	}
+
+	fitness := make([]float64, len(genomes))
+	errs := make([]error, len(requests))
+	var wg sync.WaitGroup
+	for k, req := range requests {
+		start := k * size
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[k] = c.send(ctx, workers, k, req, fitness[start:start+len(req.Genomes)])
+		}()
+	}
+	wg.Wait()
+	return fitness, errors.Join(errs...)
+}
+
+func (c *Coordinator) snapshot() []worker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.workers)
+}
+
+// send evaluates the request on the k-th worker, moving on to the next ones
+// when it fails, and writes the fitness values to fitness. A worker reporting
+// that the fitness function failed is not retried.
+func (c *Coordinator) send(ctx context.Context, workers []worker, k int, req *pb.EvaluationRequest, fitness []float64) error {
+	var err error
+	for attempt := range max(c.Retries, 0) + 1 {
+		w := workers[(k+attempt)%len(workers)]
+		reqCtx, cancel := ctx, context.CancelFunc(func() {})
+		if c.Timeout > 0 {
+			reqCtx, cancel = context.WithTimeout(ctx, c.Timeout)
+		}
+		var res *pb.EvaluationResponse
+		res, err = w.evaluate(reqCtx, req)
+		cancel()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			err = fmt.Errorf("simulation: worker %s: %w", w.address, err)
+			sometinyai.Logger().Warn("worker failed", "worker", w.address, "attempt", attempt, "err", err)
+			continue
+		}
+		if res.GetError() != "" {
+			return fmt.Errorf("simulation: worker %s: %s", w.address, res.GetError())
+		}
+		if len(res.GetFitness()) != len(fitness) {
+			return fmt.Errorf("simulation: worker %s returned %d values, want %d", w.address, len(res.GetFitness()), len(fitness))
+		}
+		copy(fitness, res.GetFitness())
+		return nil
+	}
+	return err
+}
+
+// exchange posts the request to the worker at address with do, and reads
+// its response.
+func exchange(
+	ctx context.Context,
+	address string,
+	req *pb.EvaluationRequest,
+	do func(*http.Request) (*http.Response, error),
+) (*pb.EvaluationResponse, error) {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, address, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Content-Type", "application/x-protobuf")
+	res, err := do(r)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.New(res.Status)
+	}
+	out, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	response := &pb.EvaluationResponse{}
+	if err := proto.Unmarshal(out, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// serve calls the handler of a loopback worker, and gives up when the
+// request is cancelled, leaving it running.
+func serve(h http.Handler, r *http.Request) (*http.Response, error) {
+	done := make(chan *recorder, 1)
+	go func() {
+		rec := &recorder{header: http.Header{}, status: http.StatusOK}
+		h.ServeHTTP(rec, r)
+		done <- rec
+	}()
+	select {
+	case rec := <-done:
+		return &http.Response{
+			Status:     fmt.Sprintf("%d %s", rec.status, http.StatusText(rec.status)),
+			StatusCode: rec.status,
+			Header:     rec.header,
+			Body:       io.NopCloser(&rec.body),
+		}, nil
+	case <-r.Context().Done():
+		return nil, r.Context().Err()
+	}
+}
+
+// recorder is the http.ResponseWriter of a loopback worker.
+type recorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *recorder) Header() http.Header         { return r.header }
+func (r *recorder) WriteHeader(status int)      { r.status = status }
+func (r *recorder) Write(b []byte) (int, error) { return r.body.Write(b) }
+
+// Worker evaluates the genomes sent by a Coordinator, concurrently, serving
+// EvaluationRequest messages over HTTP with POST. Genomes are loaded with a
+// random source seeded by the coordinator, so seeded trainings stay
+// reproducible, and their activations must be registered.
+type Worker[D any] struct {
+	Fitness func(*sometinyai.Genome, D) float64
+	Decode  func([]byte) (D, error) // Reads the mutable data sent with EncodeData, the zero value without it
+	Workers int                     // Evaluations running at once across requests, GOMAXPROCS while zero
+
+	once  sync.Once
+	slots chan struct{}
+}
+
+func (w *Worker[D]) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	in, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	out, err := proto.Marshal(w.handle(in))
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/x-protobuf")
+	rw.Write(out)
+}
+
+// handle evaluates a serialized request. Failures are reported in the
+// response, so that the coordinator does not retry them.
+func (w *Worker[D]) handle(in []byte) *pb.EvaluationResponse {
+	fail := func(err error) *pb.EvaluationResponse {
+		return &pb.EvaluationResponse{Error: err.Error()}
+	}
+	req := &pb.EvaluationRequest{}
+	if err := proto.Unmarshal(in, req); err != nil {
+		return fail(err)
+	}
+	var data D
+	if w.Decode != nil {
+		var err error
+		if data, err = w.Decode(req.GetData()); err != nil {
+			return fail(err)
+		}
+	}
+	genomes := make([]*sometinyai.Genome, len(req.GetGenomes()))
+	for i, m := range req.GetGenomes() {
+		g, err := sometinyai.GenomeFromProto(m)
+		if err != nil {
+			return fail(fmt.Errorf("genome %d: %w", i, err))
+		}
+		if i < len(req.GetSeeds()) {
+			seed := req.GetSeeds()[i]
+			g.SetRand(rand.New(rand.NewPCG(seed, seed)))
+		}
+		genomes[i] = g
+	}
+
+	w.once.Do(func() {
+		n := w.Workers
+		if n <= 0 {
+			n = runtime.GOMAXPROCS(0)
+		}
+		w.slots = make(chan struct{}, n)
+	})
+	fitness := make([]float64, len(genomes))
+	errs := make([]error, len(genomes))
+	var wg sync.WaitGroup
+	for i, g := range genomes {
+		w.slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-w.slots }()
+			defer func() {
+				if r := recover(); r != nil {
+					errs[i] = fmt.Errorf("fitness of genome %d panicked: %v", i, r)
+				}
+			}()
+			fitness[i] = w.Fitness(g, data)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return fail(err)
+		}
+	}
+	return &pb.EvaluationResponse{Fitness: fitness}
+}
@@ -0,0 +1,275 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/matwate/sometinyai"
+	"github.com/matwate/sometinyai/activation"
+	pb "github.com/matwate/sometinyai/protos"
+)
+
+func testGenomes(n int) []*sometinyai.Genome {
+	genomes := make([]*sometinyai.Genome, n)
+	for i := range genomes {
+		genomes[i] = sometinyai.NewGenome(2, 1, activation.Sigmoid)
+		for range i {
+			genomes[i].SplitConnection()
+		}
+		genomes[i].SetRand(rand.New(rand.NewPCG(uint64(i), 0)))
+	}
+	return genomes
+}
+
+func nodes(g *sometinyai.Genome, _ interface{}) float64 {
+	n, _ := g.Size()
+	return float64(n)
+}
+
+// failing answers every request with an internal error.
+type failing struct{ calls atomic.Int32 }
+
+func (f *failing) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.calls.Add(1)
+	http.Error(w, "out of memory", http.StatusInternalServerError)
+}
+
+// slow answers once the request is cancelled.
+type slow struct{ calls atomic.Int32 }
+
+func (s *slow) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.calls.Add(1)
+	<-r.Context().Done()
+}
+
+func checkNodes(t *testing.T, genomes []*sometinyai.Genome, fitness []float64) {
+	t.Helper()
+	if len(fitness) != len(genomes) {
+		t.Fatalf("got %d values, want %d", len(fitness), len(genomes))
+	}
+	for i, g := range genomes {
+		if want := nodes(g, nil); fitness[i] != want {
+			t.Errorf("genome %d: fitness %f, want %f", i, fitness[i], want)
+		}
+	}
+}
+
+func TestCoordinatorEvaluate(t *testing.T) {
+	c := NewCoordinator()
+	for range 3 {
+		c.RegisterLoopback(&Worker[interface{}]{Fitness: nodes})
+	}
+	genomes := testGenomes(10)
+	fitness, err := c.Evaluate(context.Background(), genomes, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkNodes(t, genomes, fitness)
+}
+
+func TestCoordinatorRetriesFailingWorker(t *testing.T) {
+	bad := &failing{}
+	c := NewCoordinator(Retries(1))
+	c.RegisterLoopback(bad)
+	c.RegisterLoopback(&Worker[interface{}]{Fitness: nodes})
+	genomes := testGenomes(4)
+	fitness, err := c.Evaluate(context.Background(), genomes, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkNodes(t, genomes, fitness)
+	if bad.calls.Load() == 0 {
+		t.Error("the failing worker was never asked")
+	}
+
+	c = NewCoordinator(Retries(0))
+	c.RegisterLoopback(bad)
+	if _, err := c.Evaluate(context.Background(), genomes, nil); err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("without retries: got error %v, want the worker's status", err)
+	}
+}
+
+func TestCoordinatorRequestTimeout(t *testing.T) {
+	stuck := &slow{}
+	c := NewCoordinator(RequestTimeout(20*time.Millisecond), Retries(1))
+	c.RegisterLoopback(stuck)
+	c.RegisterLoopback(&Worker[interface{}]{Fitness: nodes})
+	genomes := testGenomes(4)
+	start := time.Now()
+	fitness, err := c.Evaluate(context.Background(), genomes, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkNodes(t, genomes, fitness)
+	if stuck.calls.Load() == 0 {
+		t.Error("the slow worker was never asked")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("took %v, the timeout did not apply", elapsed)
+	}
+}
+
+func TestCoordinatorWorkerError(t *testing.T) {
+	other := &failing{}
+	c := NewCoordinator(Retries(2))
+	c.RegisterLoopback(&Worker[interface{}]{Fitness: func(*sometinyai.Genome, interface{}) float64 { panic("boom") }})
+	c.RegisterLoopback(other)
+	_, err := c.Evaluate(context.Background(), testGenomes(1), nil)
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("got error %v, want the panic", err)
+	}
+	if other.calls.Load() != 0 {
+		t.Error("a failing fitness function was retried on another worker")
+	}
+}
+
+func TestCoordinatorNoWorker(t *testing.T) {
+	if _, err := NewCoordinator().Evaluate(context.Background(), testGenomes(1), nil); err == nil {
+		t.Fatal("evaluated without workers")
+	}
+}
+
+func TestCoordinatorSeeds(t *testing.T) {
+	c := NewCoordinator()
+	c.RegisterLoopback(&Worker[interface{}]{Fitness: func(g *sometinyai.Genome, _ interface{}) float64 {
+		return g.Rand().Float64()
+	}})
+	genomes := testGenomes(3)
+	fitness, err := c.Evaluate(context.Background(), genomes, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range genomes {
+		// The seed sent is the next value of the genome's random source
+		seed := rand.New(rand.NewPCG(uint64(i), 0)).Uint64()
+		if want := rand.New(rand.NewPCG(seed, seed)).Float64(); fitness[i] != want {
+			t.Errorf("genome %d: drew %f, want %f", i, fitness[i], want)
+		}
+	}
+}
+
+func TestCoordinatorEncodeData(t *testing.T) {
+	c := NewCoordinator(EncodeData(func(offset float64) ([]byte, error) {
+		return []byte{byte(offset)}, nil
+	}))
+	c.RegisterLoopback(&Worker[float64]{
+		Fitness: func(g *sometinyai.Genome, offset float64) float64 { return nodes(g, nil) + offset },
+		Decode:  func(b []byte) (float64, error) { return float64(b[0]), nil },
+	})
+	genomes := testGenomes(2)
+	fitness, err := c.Evaluate(context.Background(), genomes, 10.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, g := range genomes {
+		if want := nodes(g, nil) + 10; fitness[i] != want {
+			t.Errorf("genome %d: fitness %f, want %f", i, fitness[i], want)
+		}
+	}
+}
+
+func TestCoordinatorRegistration(t *testing.T) {
+	c := NewCoordinator(AcceptWorkers(func(_ *http.Request, address string) bool {
+		return strings.HasPrefix(address, "http://10.0.0.")
+	}))
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	if err := RegisterWorker(context.Background(), nil, server.URL, "http://10.0.0.1:8080"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterWorker(context.Background(), server.Client(), server.URL, "http://attacker.example"); err == nil {
+		t.Error("registered a worker the filter rejects")
+	}
+	if got := c.Workers(); len(got) != 1 || got[0] != "http://10.0.0.1:8080" {
+		t.Fatalf("workers %v", got)
+	}
+
+	body, _ := proto.Marshal(&pb.WorkerRegistration{Address: "http://10.0.0.1:8080"})
+	req, _ := http.NewRequest(http.MethodDelete, server.URL, bytes.NewReader(body))
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if got := c.Workers(); len(got) != 0 {
+		t.Errorf("workers %v after unregistering", got)
+	}
+}
+
+func TestCoordinatorRegistrationWithoutFilter(t *testing.T) {
+	c := NewCoordinator()
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	if err := RegisterWorker(context.Background(), nil, server.URL, "http://10.0.0.1:8080"); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("got error %v, want the registration forbidden", err)
+	}
+	if got := c.Workers(); len(got) != 0 {
+		t.Errorf("registered %v without AcceptWorkers", got)
+	}
+}
+
+// countingTransport counts the requests it passes on.
+type countingTransport struct{ calls atomic.Int32 }
+
+func (c *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	c.calls.Add(1)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestCoordinatorHTTPClient(t *testing.T) {
+	server := httptest.NewServer(&Worker[interface{}]{Fitness: nodes})
+	defer server.Close()
+	transport := &countingTransport{}
+	c := NewCoordinator(HTTPClient(&http.Client{Transport: transport}))
+	c.Register(server.URL)
+	genomes := testGenomes(3)
+	fitness, err := c.Evaluate(context.Background(), genomes, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkNodes(t, genomes, fitness)
+	if transport.calls.Load() == 0 {
+		t.Error("the configured client was not used")
+	}
+}
+
+func TestWorkerConcurrency(t *testing.T) {
+	var running, peak atomic.Int32
+	w := &Worker[interface{}]{Workers: 2, Fitness: func(g *sometinyai.Genome, data interface{}) float64 {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return nodes(g, data)
+	}}
+	// Every loopback sends its own request, all of them at once
+	c := NewCoordinator()
+	for range 3 {
+		c.RegisterLoopback(w)
+	}
+	genomes := testGenomes(12)
+	fitness, err := c.Evaluate(context.Background(), genomes, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkNodes(t, genomes, fitness)
+	if got := peak.Load(); got > 2 {
+		t.Errorf("%d evaluations ran at once, want at most 2", got)
+	}
+}
@@ -0,0 +1,223 @@
+package simulation
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sort"
+)
+
+// Selector picks the parents of the next generation's children.
+type Selector interface {
+	// Select draws n parents from candidates whose scores are sorted best
+	// first, higher being better, and returns their indices. Agents that
+	// failed to evaluate score -Inf.
+	Select(scores []float64, n int, r *rand.Rand) []int
+}
+
+// Tournament picks the best of Size candidates drawn at random, with
+// replacement, for every parent.
+type Tournament struct {
+	Size int
+}
+
+func (t Tournament) Select(scores []float64, n int, r *rand.Rand) []int {
+	selected := make([]int, n)
+	for i := range selected {
+		best := r.IntN(len(scores))
+		for range max(t.Size, 1) - 1 {
+			if c := r.IntN(len(scores)); scores[c] > scores[best] {
+				best = c
+			}
+		}
+		selected[i] = best
+	}
+	return selected
+}
+
+// Roulette picks every parent with a probability proportional to its score,
+// shifted so that the worst candidate has almost none.
+type Roulette struct{}
+
+func (Roulette) Select(scores []float64, n int, r *rand.Rand) []int {
+	cumulative := cumulate(shifted(scores))
+	selected := make([]int, n)
+	for i := range selected {
+		selected[i] = spin(cumulative, r.Float64()*cumulative[len(cumulative)-1])
+	}
+	return selected
+}
+
+// Rank picks every parent with a probability proportional to its rank, from
+// the number of candidates for the best down to one for the worst, so that
+// the spread of the scores does not matter.
+type Rank struct{}
+
+func (Rank) Select(scores []float64, n int, r *rand.Rand) []int {
+	weights := make([]float64, len(scores))
+	for i := range weights {
+		weights[i] = float64(len(scores) - i)
+	}
+	cumulative := cumulate(weights)
+	selected := make([]int, n)
+	for i := range selected {
+		selected[i] = spin(cumulative, r.Float64()*cumulative[len(cumulative)-1])
+	}
+	return selected
+}
+
+// Truncation picks parents uniformly among the candidates. Along with
+// SurvivalFraction, that limits the candidates to the best agents, it is the
+// classic truncation selection.
+type Truncation struct{}
+
+func (Truncation) Select(scores []float64, n int, r *rand.Rand) []int {
+	selected := make([]int, n)
+	for i := range selected {
+		selected[i] = r.IntN(len(scores))
+	}
+	return selected
+}
+
+// StochasticUniversal is fitness proportional like Roulette, but draws every
+// parent from a single spin with n evenly spaced pointers, so that the number
+// of children of each candidate stays close to its expected value.
+type StochasticUniversal struct{}
+
+func (StochasticUniversal) Select(scores []float64, n int, r *rand.Rand) []int {
+	cumulative := cumulate(shifted(scores))
+	step := cumulative[len(cumulative)-1] / float64(n)
+	start := r.Float64() * step
+	selected := make([]int, n)
+	for i := range selected {
+		selected[i] = spin(cumulative, start+float64(i)*step)
+	}
+	return selected
+}
+
+// Selection makes the parents be picked by sel. Without one, the candidates
+// allowed by SurvivalFraction take turns, best first.
+func Selection(sel Selector) Option {
+	return func(o *Settings) { o.Selector = sel }
+}
+
+// Elitism sets how many of the best agents are kept unchanged in the next
+// generation, a third of the population by default. It has to leave room for
+// at least one child. With Speciation every species keeps its best agent
+// instead.
+func Elitism(n int) Option {
+	return func(o *Settings) { o.Elitism = n }
+}
+
+// SurvivalFraction sets the fraction of the best agents, of the population or
+// of each species, that can be picked as parents, above 0 and up to 1. It
+// defaults to a third.
+func SurvivalFraction(f float64) Option {
+	return func(o *Settings) { o.SurvivalFraction = f }
+}
+
+// shifted returns the scores shifted so that the worst finite one is almost
+// zero, with zero for the agents that failed. When every agent failed they
+// all get the same weight.
+func shifted(scores []float64) []float64 {
+	worst := math.Inf(1)
+	for _, score := range scores {
+		if !math.IsInf(score, 0) {
+			worst = min(worst, score)
+		}
+	}
+	weights := make([]float64, len(scores))
+	var total float64
+	for i, score := range scores {
+		if !math.IsInf(score, 0) {
+			weights[i] = score - worst + 1e-9
+			total += weights[i]
+		}
+	}
+	if total == 0 {
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+	return weights
+}
+
+func cumulate(weights []float64) []float64 {
+	cumulative := slices.Clone(weights)
+	for i := 1; i < len(cumulative); i++ {
+		cumulative[i] += cumulative[i-1]
+	}
+	return cumulative
+}
+
+// spin returns the index of the slice of the wheel that x falls in.
+func spin(cumulative []float64, x float64) int {
+	i := sort.SearchFloat64s(cumulative, x)
+	return min(i, len(cumulative)-1)
+}
+
+// validateSelection checks that breeding has parents to pick from and room
+// for children.
+func (s *Simulation[D]) validateSelection() error {
+	if f := s.Config.SurvivalFraction; !(f > 0 && f <= 1) {
+		return fmt.Errorf("simulation: SurvivalFraction %v is not in (0, 1]", f)
+	}
+	if s.Config.CompatibilityThreshold <= 0 && s.Config.Elitism >= s.Config.PopulationSize {
+		return fmt.Errorf("simulation: Elitism %d leaves no room for children in a population of %d",
+			s.Config.Elitism, s.Config.PopulationSize)
+	}
+	return nil
+}
+
+// survivors returns how many of n sorted agents can be picked as parents.
+func (s *Simulation[D]) survivors(n int) int {
+	return min(n, max(1, int(float64(n)*s.Config.SurvivalFraction)))
+}
+
+// elites returns how many of n sorted agents are kept unchanged.
+func (s *Simulation[D]) elites(n int) int {
+	if s.Config.Elitism < 0 {
+		return n / 3
+	}
+	return min(s.Config.Elitism, n)
+}
+
+// selectParents returns the indices of n parents among the sorted
+// candidates.
+func (s *Simulation[D]) selectParents(candidates Population, n int) []int {
+	selected := make([]int, n)
+	if n == 0 {
+		return selected
+	}
+	if s.Config.Selector == nil {
+		for i := range selected {
+			selected[i] = i % len(candidates)
+		}
+		return selected
+	}
+	return s.Config.Selector.Select(s.scores(candidates), n, s.rng)
+}
+
+// mate returns the index of the candidate that breeds with candidate a by
+// crossover, any other one with equal chance unless a Selector is set.
+func (s *Simulation[D]) mate(candidates Population, a int) int {
+	if s.Config.Selector != nil {
+		if b := s.Config.Selector.Select(s.scores(candidates), 1, s.rng)[0]; b != a {
+			return b
+		}
+	}
+	b := s.rng.IntN(len(candidates) - 1)
+	if b >= a {
+		b++
+	}
+	return b
+}
+
+func (s *Simulation[D]) scores(candidates Population) []float64 {
+	scores := make([]float64, len(candidates))
+	for i, agent := range candidates {
+		scores[i] = s.rankScore(agent)
+	}
+	return scores
+}
@@ -0,0 +1,108 @@
+package simulation
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/matwate/sometinyai/activation"
+)
+
+func TestSelectors(t *testing.T) {
+	// Sorted best first, the last two failed
+	scores := []float64{5, 4, 3, 2, 1, math.Inf(-1), math.Inf(-1)}
+	tests := []struct {
+		name        string
+		sel         Selector
+		skipsFailed bool // Failed candidates are never picked
+		favoursBest bool // The best candidate is picked more than the worst finite one
+	}{
+		{"tournament", Tournament{Size: 3}, false, true},
+		{"roulette", Roulette{}, true, true},
+		{"rank", Rank{}, false, true},
+		{"truncation", Truncation{}, false, false},
+		{"stochastic universal", StochasticUniversal{}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const n = 7000
+			selected := tt.sel.Select(scores, n, rand.New(rand.NewPCG(1, 2)))
+			if len(selected) != n {
+				t.Fatalf("selected %d parents, want %d", len(selected), n)
+			}
+			counts := make([]int, len(scores))
+			for _, i := range selected {
+				if i < 0 || i >= len(scores) {
+					t.Fatalf("selected candidate %d of %d", i, len(scores))
+				}
+				counts[i]++
+			}
+			if tt.skipsFailed && counts[5]+counts[6] > 0 {
+				t.Errorf("picked failed candidates: %v", counts)
+			}
+			if tt.favoursBest && counts[0] <= counts[4] {
+				t.Errorf("the best is not favoured: %v", counts)
+			}
+			if !tt.favoursBest {
+				// Uniform, within a generous margin
+				for i, c := range counts {
+					if c < n/len(scores)/2 || c > 2*n/len(scores) {
+						t.Errorf("candidate %d picked %d times: %v", i, c, counts)
+					}
+				}
+			}
+
+			// Every candidate failed
+			failed := []float64{math.Inf(-1), math.Inf(-1), math.Inf(-1)}
+			for _, i := range tt.sel.Select(failed, 10, rand.New(rand.NewPCG(1, 2))) {
+				if i < 0 || i >= len(failed) {
+					t.Fatalf("selected candidate %d of %d when all failed", i, len(failed))
+				}
+			}
+		})
+	}
+}
+
+func TestStochasticUniversalExpected(t *testing.T) {
+	// Shifted, the weights are about 2, 1 and 0
+	scores := []float64{3, 2, 1}
+	counts := make([]int, len(scores))
+	for _, i := range (StochasticUniversal{}).Select(scores, 30, rand.New(rand.NewPCG(1, 2))) {
+		counts[i]++
+	}
+	for i, want := range []int{20, 10, 0} {
+		if d := counts[i] - want; d < -1 || d > 1 {
+			t.Errorf("candidate %d picked %d times, want %d", i, counts[i], want)
+		}
+	}
+}
+
+func TestSelectionOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		err  string
+	}{
+		{"zero survivors", []Option{SurvivalFraction(0)}, "SurvivalFraction"},
+		{"more than all survivors", []Option{SurvivalFraction(1.5)}, "SurvivalFraction"},
+		{"NaN survivors", []Option{SurvivalFraction(math.NaN())}, "SurvivalFraction"},
+		{"all elites", []Option{Elitism(10)}, "Elitism"},
+		{"all survive", []Option{SurvivalFraction(1), Elitism(9)}, ""},
+		{"species ignore elitism", []Option{Speciation(1), Elitism(10)}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{PopulationSize(10), Iterations(3), Fitness(xor)}, tt.opts...)
+			s := NewSimulation(2, 1, activation.Sigmoid, opts...)
+			_, err := s.TrainContext(context.Background())
+			if tt.err == "" && err != nil {
+				t.Fatal(err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Fatalf("got error %v, want one about %s", err, tt.err)
+			}
+		})
+	}
+}
@@ -2,10 +2,12 @@ package simulation
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"log/slog"
 	"math"
+	"math/rand/v2"
+	"slices"
 	"sort"
-	"sync"
 	"time"
 
 	"github.com/matwate/sometinyai"
@@ -14,27 +16,135 @@ import (
 
 type (
 	Agent struct {
-		Genome  *sometinyai.Genome
-		Fitness float64
+		Genome        *sometinyai.Genome
+		Fitness       float64
+		SharedFitness float64 // Score shared within the species, only set with Speciation
+		Failed        bool    // Its evaluation did not finish in time, Fitness is the worst one
+
+		// Only set with Objectives
+		Objectives []float64 // Nil when the evaluation failed
+		Rank       int       // Pareto front, from 0, -1 when the evaluation failed
+		Crowding   float64
+
+		// Only set with Novelty
+		Behavior []float64 // Nil when the evaluation failed
+		Novelty  float64
+		Score    float64 // Blend of novelty and fitness the agents are ranked by, or their Pareto ranking
 	}
 	ThresholdBreak int
 	Population     []Agent
-	Simulation     struct {
-		Population Population
-		Config     *Options
+	// Simulation evolves a population of genomes whose fitness functions
+	// get mutable data of type D.
+	Simulation[D any] struct {
+		Population  Population
+		Config      *Options[D]
+		Innovations *sometinyai.InnovationTracker // Shared by every genome of the population
+		Generation  int                           // Generations run so far
+		History     History
+		Species     []*Species // Only used with Speciation
+		nextSpecies int
+		best        Agent       // Best agent of the last evaluated generation
+		ranked      Population  // Last evaluated generation, sorted best first
+		bestScore   float64     // Best score of any generation, see score
+		front       Population  // Only with Objectives
+		archive     [][]float64 // Novel behaviors, only with Novelty
+		pcg         *rand.PCG
+		rng         *rand.Rand    // Draws from pcg, only used by the training goroutine
+		slots       chan struct{} // Held by every running evaluation, see Workers
+
+		// What fresh genomes are created with
+		inputs, outputs int
+		act             func(float64) float64
+
+		// Stagnation detection, see Stagnation
+		plateau      int     // Generations since the best score last improved
+		plateauScore float64 // Best score when it last improved
+		boost        float64 // Factor of the mutation rates, see MutationBoost
+		hallOfFame   Population
+		halted       bool // Stopped early on stagnation
+
+		// Progress through the curriculum, see WithCurriculum
+		stage            int // Current stage, len(Curriculum) once done
+		stageGenerations int // Generations run in the current stage
+		stageStreak      int // Generations in a row that reached its threshold
+	}
+	// Options of a simulation whose mutable data is of type D.
+	Options[D any] struct {
+		Settings
+		Fitness         func(*sometinyai.Genome, D) float64 // Now takes mutable data
+		MutableData     D
+		SuccessCallback func(float64, D) (D, bool)
+		Curriculum      Curriculum[D]                           // See WithCurriculum
+		FitnessBatch    func([]*sometinyai.Genome, D) []float64 // See FitnessBatch
+		Objectives      func(*sometinyai.Genome, D) []float64   // See Objectives
+		Behavior        func(*sometinyai.Genome, D) []float64   // See Novelty
 	}
-	Options struct {
+	// Settings are the options that do not depend on the type of the
+	// mutable data.
+	Settings struct {
 		PopulationSize    int
 		MutationCount     int
 		Iterations        int
-		Fitness           func(*sometinyai.Genome, interface{}) float64 // Now takes mutable data
 		Threshold         ThresholdBreak
 		ThresholdValue    float64
-		MutableData       interface{}
-		SuccessCallback   func(float64, interface{}) (interface{}, bool)
+		Tolerance         float64 // How close the Closest threshold must be reached
+		CrossoverRate     float64 // Fraction of the offspring bred by crossover instead of cloning
+		Observers         []Observer
+		Logger            *slog.Logger // Falls back to sometinyai.Logger
+		Recurrent         bool         // Genomes may have cycles, see sometinyai.Recurrent
+		Mutation          sometinyai.MutationConfig
 		generationTimeout time.Duration
+		seed              uint64
+		seeded            bool
+		typed             []func(any) error // Options of the typed fields, see typed
+		err               error             // Left by a typed option that does not fit
+
+		// Activation of the output nodes, only used while FixedOutputs is set
+		OutputActivation activation.ActivationFunction
+		FixedOutputs     bool
+
+		// Speciation, disabled while CompatibilityThreshold is zero
+		CompatibilityThreshold float64
+		ExcessCoefficient      float64
+		DisjointCoefficient    float64
+		WeightCoefficient      float64
+		StagnationLimit        int
+
+		// Evaluation, see Workers
+		Workers      int
+		AgentTimeout time.Duration
+		Coordinator  *Coordinator // Evaluates on remote workers, see Distributed
+
+		// Multi-objective evolution, see Objectives
+		Directions []Direction
+
+		// Novelty search, see Novelty
+		NoveltyNeighbours int
+		NoveltyWeight     float64
+		ArchiveThreshold  float64
+		ArchiveSize       int
+
+		// Stagnation of the whole population, disabled while
+		// StagnationGenerations is zero
+		StagnationGenerations int
+		StagnationEpsilon     float64
+		StagnationPolicy      StagnationPolicy
+		MutationBoost         float64
+		InjectFraction        float64
+		HallOfFameSize        int
+
+		// Breeding, see Selection
+		Selector         Selector
+		Elitism          int // A third of the population when negative
+		SurvivalFraction float64
+
+		// Automatic checkpoints, disabled while CheckpointEvery is zero
+		CheckpointDir   string
+		CheckpointEvery int
 	}
-	Option func(*Options)
+	// Option sets options of a simulation, whatever the type of its mutable
+	// data, see TypedOption.
+	Option = func(*Settings)
 )
 
 const (
@@ -44,172 +154,646 @@ const (
 )
 
 func PopulationSize(size int) Option {
-	return func(o *Options) { o.PopulationSize = size }
+	return func(o *Settings) { o.PopulationSize = size }
 }
 
 func MutationCount(count int) Option {
-	return func(o *Options) { o.MutationCount = count }
+	return func(o *Settings) { o.MutationCount = count }
 }
 
 func Iterations(iter int) Option {
-	return func(o *Options) { o.Iterations = iter }
+	return func(o *Settings) { o.Iterations = iter }
 }
 
 func Threshold(threshold ThresholdBreak, value float64) Option {
-	return func(o *Options) {
+	return func(o *Settings) {
 		o.Threshold = threshold
 		o.ThresholdValue = value
 	}
 }
 
+// Tolerance sets how close to the threshold value the best fitness has to
+// be to reach it with Closest, 0.0001 by default.
+func Tolerance(t float64) Option {
+	return func(o *Settings) { o.Tolerance = t }
+}
+
+// UseMutableData is MutableData for untyped simulations.
 func UseMutableData(
 	initialData interface{},
 	successCB func(float64, interface{}) (interface{}, bool),
 ) Option {
-	return func(o *Options) {
-		o.MutableData = initialData
-		o.SuccessCallback = successCB
+	return MutableData(initialData, successCB)
+}
+
+func Fitness[D any](f func(*sometinyai.Genome, D) float64) TypedOption[D] {
+	return typed("Fitness", func(o *Options[D]) { o.Fitness = f })
+}
+
+// Mutation sets the probabilities and step sizes of the mutations applied to
+// every child, see sometinyai.MutationConfig.
+func Mutation(config sometinyai.MutationConfig) Option {
+	return func(o *Settings) { o.Mutation = config }
+}
+
+// CrossoverRate makes each non elite child the crossover of two elites with
+// probability p, instead of a clone of one. Children are mutated either way.
+func CrossoverRate(p float64) Option {
+	return func(o *Settings) { o.CrossoverRate = p }
+}
+
+// Recurrent makes the population out of recurrent genomes. Fitness functions
+// evaluating them with Step should Reset the genome first.
+func Recurrent() Option {
+	return func(o *Settings) { o.Recurrent = true }
+}
+
+// OutputActivation fixes the activation of every output node to a, hidden
+// nodes keep evolving theirs.
+func OutputActivation(a activation.ActivationFunction) Option {
+	return func(o *Settings) {
+		o.OutputActivation = a
+		o.FixedOutputs = true
 	}
 }
 
-func Fitness(f func(*sometinyai.Genome, interface{}) float64) Option {
-	return func(o *Options) { o.Fitness = f }
+// WithLogger makes the simulation log to l: a summary of every generation at
+// info level, and timed out generations at warning level.
+func WithLogger(l *slog.Logger) Option {
+	return func(o *Settings) { o.Logger = l }
+}
+
+// Workers caps the number of goroutines evaluating the population, which
+// otherwise gets one per agent.
+func Workers(n int) Option {
+	return func(o *Settings) { o.Workers = n }
+}
+
+// AgentTimeout gives every agent at most d to be evaluated, after which it is
+// considered failed and gets the worst possible fitness. Its worker waits for
+// the evaluation to return before taking the next one, so that there are never
+// more evaluations running than Workers, even across generations. Fitness
+// functions that never return hold their worker for good: once they all do,
+// the training blocks until ctx is done or the generation times out, see
+// WithTimeout, which Train has neither of.
+func AgentTimeout(d time.Duration) Option {
+	return func(o *Settings) { o.AgentTimeout = d }
+}
+
+// FitnessBatch evaluates the population with f, which gets a batch of
+// genomes at once and returns their fitness in the same order, instead of the
+// fitness function. The population is split in one batch per worker, and a
+// batch times out after the AgentTimeout of all its agents.
+func FitnessBatch[D any](f func([]*sometinyai.Genome, D) []float64) TypedOption[D] {
+	return typed("FitnessBatch", func(o *Options[D]) { o.FitnessBatch = f })
 }
 
+// WithTimeout gives every generation at most d to be evaluated, after which
+// the agents not evaluated yet are considered failed and get the worst
+// possible fitness.
 func WithTimeout(d time.Duration) Option {
-	return func(o *Options) { o.generationTimeout = d }
+	return func(o *Settings) { o.generationTimeout = d }
+}
+
+// Seed makes the training reproducible: the population, the breeding and
+// the mutations are drawn from a generator seeded with seed. Every agent is
+// given a stream of its own, derived from it, before being evaluated, which
+// the fitness function can draw from with Genome.Rand. Generations that time
+// out are not reproducible.
+func Seed(seed uint64) Option {
+	return func(o *Settings) {
+		o.seed = seed
+		o.seeded = true
+	}
+}
+
+// NewSimulation creates an untyped simulation, whose mutable data can be
+// anything, see NewTypedSimulation. A TypedOption whose type is not
+// interface{} still compiles here, TrainContext returns its error.
+func NewSimulation(inputs, outputs int, act func(float64) float64, opts ...Option) Simulation[interface{}] {
+	return NewTypedSimulation(inputs, outputs, act, untyped(opts)...)
 }
 
-func NewSimulation(inputs, outputs int, act func(float64) float64, opts ...Option) Simulation {
-	options := &Options{
+// NewTypedSimulation creates a simulation whose fitness functions and
+// success callback get mutable data of type D.
+func NewTypedSimulation[D any](inputs, outputs int, act func(float64) float64, opts ...TypedOption[D]) Simulation[D] {
+	options := newOptions[D](defaultSettings(), opts)
+
+	seed := options.seed
+	if !options.seeded {
+		seed = rand.Uint64()
+	}
+	pcg := rand.NewPCG(seed, seed)
+	rng := rand.New(pcg)
+
+	innovations := sometinyai.NewInnovationTracker(inputs, outputs)
+	return Simulation[D]{
+		Population:  newPopulation(options.PopulationSize, inputs, outputs, act, &options.Settings, innovations, rng),
+		Config:      options,
+		Innovations: innovations,
+		bestScore:   math.Inf(-1),
+		pcg:         pcg,
+		rng:         rng,
+		inputs:      inputs,
+		outputs:     outputs,
+		act:         act,
+
+		plateauScore: math.Inf(-1),
+		boost:        1,
+	}
+}
+
+func defaultSettings() Settings {
+	return Settings{
 		PopulationSize: 100,
 		MutationCount:  2,
 		Iterations:     1000,
 		Threshold:      Highest,
-	}
+		Tolerance:      0.0001,
+		Mutation:       sometinyai.DefaultMutationConfig(),
 
-	for _, opt := range opts {
-		opt(options)
-	}
+		ExcessCoefficient:   1,
+		DisjointCoefficient: 1,
+		WeightCoefficient:   0.4,
+		StagnationLimit:     15,
 
-	return Simulation{
-		Population: newPopulation(options.PopulationSize, inputs, outputs, act),
-		Config:     options,
+		Elitism:          -1,
+		SurvivalFraction: 1.0 / 3,
+
+		NoveltyWeight: 1,
+
+		MutationBoost:  2,
+		InjectFraction: 0.5,
+		HallOfFameSize: 5,
 	}
 }
 
-func newPopulation(size, inputs, outputs int, act func(float64) float64) Population {
+func newPopulation(
+	size, inputs, outputs int,
+	act func(float64) float64,
+	options *Settings,
+	innovations *sometinyai.InnovationTracker,
+	rng *rand.Rand,
+) Population {
 	if act == nil {
 		act = activation.Relu
 	}
+	genomeOpts := []sometinyai.GenomeOption{sometinyai.WithInnovationTracker(innovations)}
+	if options.Recurrent {
+		genomeOpts = append(genomeOpts, sometinyai.Recurrent())
+	}
+	if options.FixedOutputs {
+		genomeOpts = append(genomeOpts, sometinyai.OutputActivation(options.OutputActivation))
+	}
 	p := make(Population, size)
 	for i := range p {
-		p[i].Genome = sometinyai.NewGenome(inputs, outputs, act)
+		opts := append([]sometinyai.GenomeOption{sometinyai.WithRand(derive(rng))}, genomeOpts...)
+		p[i].Genome = sometinyai.NewGenome(inputs, outputs, act, opts...)
 	}
 	return p
 }
 
-func (s Simulation) Train() (Agent, interface{}) {
-	var timeout time.Duration
-	if s.Config.generationTimeout > 0 {
-		timeout = s.Config.generationTimeout
-	} else {
-		timeout = 100 * time.Minute
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Hour)
-	defer func() { fmt.Printf("Training Caneled after %v\n", ctx.Err()); cancel() }()
-	for iter := 0; iter < s.Config.Iterations; iter++ {
+// fresh returns n new random agents.
+func (s *Simulation[D]) fresh(n int) Population {
+	return newPopulation(n, s.inputs, s.outputs, s.act, &s.Config.Settings, s.Innovations, s.rng)
+}
 
-		genCtx, genCancel := context.WithTimeout(context.Background(), timeout)
-		var wg sync.WaitGroup
-		defer func() {
-			fmt.Printf("Generation %d canceled after %v \n", iter, genCtx.Err())
+// Result is the outcome of a training run.
+type Result[D any] struct {
+	Best       Agent      // Best agent of the last generation that was fully evaluated
+	Front      Population // Pareto front of that generation, only with Objectives
+	Data       D          // Mutable data as left by the success callback
+	History    History    // Statistics of every generation run so far
+	HallOfFame Population // Best agents ever, only with Stagnation
+}
 
-			genCancel()
-		}()
+// Train runs TrainContext without a deadline, and panics if it fails, on
+// invalid options or a fitness function that panics: use TrainContext to get
+// those as errors. It trains s itself, so the simulation has to be stored in
+// a variable first.
+func (s *Simulation[D]) Train() (Agent, D) {
+	res, err := s.TrainContext(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	return res.Best, res.Data
+}
 
-		// Evaluate fitness with current mutable data
-		for i := range s.Population {
-			wg.Add(1)
-			go func(i int, ctx context.Context) {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-					defer wg.Done()
-					s.Population[i].Fitness = s.Config.Fitness(
-						s.Population[i].Genome,
-						s.Config.MutableData,
-					)
-				}
-			}(i, genCtx)
+// TrainContext evolves the population until the configured number of
+// generations have been run, the success callback asks to stop, or ctx is
+// done. On cancellation it returns the best agent found so far along with
+// ctx's error. A fitness function that panics stops the training with an
+// error instead of crashing the process. Training resumes from the
+// generation the last call stopped at, so once every generation has run it
+// returns at once, unless Config.Iterations is raised.
+func (s *Simulation[D]) TrainContext(ctx context.Context) (Result[D], error) {
+	if err := s.validate(); err != nil {
+		return Result[D]{}, err
+	}
+	res, _, err := s.run(ctx, s.Config.Iterations)
+	return res, err
+}
+
+// validate checks that the options can be trained with.
+func (s *Simulation[D]) validate() error {
+	if s.Config.err != nil {
+		return s.Config.err
+	}
+	if len(s.Config.Curriculum) > 0 {
+		if err := s.validateCurriculum(); err != nil {
+			return err
 		}
-		wg.Wait()
+	} else if s.Config.Fitness == nil && s.Config.FitnessBatch == nil && s.Config.Coordinator == nil &&
+		s.Config.Objectives == nil && s.Config.Behavior == nil {
+		return errors.New("simulation: no fitness function")
+	}
+	if s.Config.Objectives != nil && s.Config.Behavior != nil {
+		return errors.New("simulation: Objectives and Novelty cannot be used together")
+	}
+	if s.Config.Objectives != nil && len(s.Config.Directions) == 0 {
+		return errors.New("simulation: Objectives needs a direction per objective")
+	}
+	if s.Config.Objectives != nil && (s.Config.FitnessBatch != nil || s.Config.Coordinator != nil) {
+		return errors.New("simulation: Objectives cannot be used with FitnessBatch or Distributed")
+	}
+	if err := s.validateSelection(); err != nil {
+		return err
+	}
+	return s.validateStagnation()
+}
 
-		// If thing was canceled, return the best agent
+// run evolves the population until generation until, or until the success
+// callback asks to stop or the curriculum is done, which it reports, or the
+// fitness stagnates with StopEarly.
+func (s *Simulation[D]) run(ctx context.Context, until int) (Result[D], bool, error) {
+	curriculum := len(s.Config.Curriculum) > 0
+	if curriculum && s.stage >= len(s.Config.Curriculum) {
+		return s.result(), true, nil
+	}
+	stagnation := s.Config.StagnationGenerations > 0
+	if s.halted {
+		return s.result(), false, nil
+	}
+	for ; s.Generation < until; s.Generation++ {
+		iter := s.Generation
+		start := time.Now()
+		if curriculum && s.stageGenerations == 0 {
+			s.startStage()
+		}
+		for _, o := range s.Config.Observers {
+			o.OnGenerationStart(iter)
+		}
+		if err := s.evaluate(ctx); err != nil {
+			return s.result(), false, err
+		}
+		if s.Config.Objectives != nil {
+			s.front = s.rankObjectives()
+		}
+		if s.Config.Behavior != nil {
+			s.rankNovelty()
+		}
 
-		// Sort population based on threshold
-		switch s.Config.Threshold {
-		case Highest:
+		if s.Config.Behavior != nil || s.Config.Objectives != nil {
 			sort.Slice(s.Population, func(i, j int) bool {
-				return s.Population[i].Fitness > s.Population[j].Fitness
-			})
-		case Lowest:
-			sort.Slice(s.Population, func(i, j int) bool {
-				return s.Population[i].Fitness < s.Population[j].Fitness
-			})
-		case Closest:
-			sort.Slice(s.Population, func(i, j int) bool {
-				return math.Abs(s.Population[i].Fitness-s.Config.ThresholdValue) <
-					math.Abs(s.Population[j].Fitness-s.Config.ThresholdValue)
+				return s.Population[i].Score > s.Population[j].Score
 			})
+		} else {
+			// Sort population based on threshold
+			switch s.Config.Threshold {
+			case Highest:
+				sort.Slice(s.Population, func(i, j int) bool {
+					return s.Population[i].Fitness > s.Population[j].Fitness
+				})
+			case Lowest:
+				sort.Slice(s.Population, func(i, j int) bool {
+					return s.Population[i].Fitness < s.Population[j].Fitness
+				})
+			case Closest:
+				sort.Slice(s.Population, func(i, j int) bool {
+					return math.Abs(s.Population[i].Fitness-s.Config.ThresholdValue) <
+						math.Abs(s.Population[j].Fitness-s.Config.ThresholdValue)
+				})
+			}
 		}
 
-		// Breed new generation
-		elite := len(s.Population) / 3
-		newPop := append(Population{}, s.Population[:elite]...)
-		for i := elite; i < len(s.Population); i++ {
-			child := s.Population[i%elite].Genome.Copy()
-			child.Mutate(s.Config.MutationCount)
-			newPop = append(newPop, Agent{Genome: child})
+		s.ranked = s.Population
+		stats := s.stats()
+		stats.Front = len(s.front)
+		s.best = s.fittest()
+		improved := s.score(s.best.Fitness) > s.bestScore
+		if improved {
+			s.bestScore = s.score(s.best.Fitness)
+		}
+		stats.Stage = s.stage
+		stats.StageCompleted = curriculum && s.progress(s.best.Fitness)
+		if stagnation {
+			s.enterHallOfFame()
+			stats.Stagnated = s.stagnated(s.best.Fitness)
+		}
+
+		// Breed new generation, its mutations get markings of their own
+		s.Innovations.NextGeneration()
+		if s.Config.CompatibilityThreshold > 0 {
+			s.Population = s.breedSpecies()
+			stats.Species = len(s.Species)
+			species := s.speciesStats()
+			for _, o := range s.Config.Observers {
+				o.OnSpeciation(iter, species)
+			}
+		} else {
+			s.Population = s.breed()
+		}
+
+		stats.Duration = time.Since(start)
+		s.History = append(s.History, stats)
+		for _, o := range s.Config.Observers {
+			if improved {
+				o.OnImprovement(iter, s.best)
+			}
+			o.OnGenerationEnd(stats)
 		}
-		s.Population = newPop
 
-		bestFitness := s.Population[0].Fitness
+		bestFitness := s.best.Fitness
 
 		// Check success condition and update mutable data
-		if s.Config.SuccessCallback != nil {
-			switch s.Config.Threshold {
-			case Highest:
-				if bestFitness >= s.Config.ThresholdValue {
-					if newData, stop := s.Config.SuccessCallback(bestFitness, s.Config.MutableData); stop {
-						return s.Population[0], newData
-					} else {
-						s.Config.MutableData = newData
-					}
+		if s.Config.SuccessCallback != nil && s.reached(bestFitness) {
+			if newData, stop := s.Config.SuccessCallback(bestFitness, s.Config.MutableData); stop {
+				s.Generation++
+				res := s.result()
+				res.Data = newData
+				return res, true, nil
+			} else {
+				s.Config.MutableData = newData
+			}
+		}
+
+		if stats.StageCompleted && s.nextStage() {
+			s.Generation++
+			return s.result(), true, nil
+		}
+		if stats.Stagnated && s.stagnate() {
+			s.Generation++
+			s.halted = true
+			return s.result(), false, nil
+		}
+
+		if s.Config.CheckpointEvery > 0 && (iter+1)%s.Config.CheckpointEvery == 0 {
+			if err := s.checkpoint(iter + 1); err != nil {
+				return s.result(), false, err
+			}
+		}
+
+		s.logger().Info("generation",
+			"generation", iter,
+			"fitness", bestFitness,
+			"mean", stats.Mean,
+			"species", stats.Species,
+			"data", s.Config.MutableData,
+		)
+	}
+
+	return s.result(), false, nil
+}
+
+func (s *Simulation[D]) result() Result[D] {
+	best := s.best
+	if best.Genome == nil {
+		// Nothing was evaluated yet
+		best = s.Population[0]
+	}
+	return Result[D]{
+		Best:       best,
+		Front:      s.front,
+		Data:       s.Config.MutableData,
+		History:    s.History,
+		HallOfFame: s.hallOfFame,
+	}
+}
+
+// evaluate computes the fitness of every agent concurrently, with the current
+// mutable data, see Workers and FitnessBatch. The population is only updated
+// once the whole generation is done. Agents still running when the
+// generation timeout or their own timeout expires get the worst possible
+// fitness, and keep running in the background since fitness functions
+// cannot be interrupted.
+func (s *Simulation[D]) evaluate(ctx context.Context) error {
+	genCtx, cancel := ctx, context.CancelFunc(func() {})
+	if s.Config.generationTimeout > 0 {
+		genCtx, cancel = context.WithTimeout(ctx, s.Config.generationTimeout)
+	}
+	defer cancel()
+
+	// Compiling up front leaves the genomes read only while they are
+	// evaluated, every agent gets a random stream of its own
+	for _, agent := range s.Population {
+		if err := agent.Genome.Compile(); err != nil {
+			return err
+		}
+		agent.Genome.SetRand(derive(s.rng))
+	}
+
+	batches := s.batches()
+	jobs := make(chan []int, len(batches))
+	for _, batch := range batches {
+		jobs <- batch
+	}
+	close(jobs)
+	results := make(chan evaluation, len(batches))
+	workers := s.Config.Workers
+	if workers <= 0 || workers > len(batches) {
+		workers = len(batches)
+	}
+	// Evaluations left running by a timeout keep their slot, the next
+	// generation only gets the others
+	if s.slots == nil {
+		n := s.Config.Workers
+		if n <= 0 {
+			n = workers
+		}
+		s.slots = make(chan struct{}, n)
+	}
+	slots := s.slots
+	// Workers left behind by a timeout must not see the next generation
+	population := slices.Clone(s.Population)
+	e := s.evaluator()
+	e.ctx = genCtx
+	for range workers {
+		go func() {
+			for batch := range jobs {
+				if genCtx.Err() != nil {
+					// Nobody is waiting for the rest anymore
+					results <- evaluation{agents: batch, timedOut: true}
+					continue
 				}
-			case Lowest:
-				if bestFitness <= s.Config.ThresholdValue {
-					if newData, stop := s.Config.SuccessCallback(bestFitness, s.Config.MutableData); stop {
-						return s.Population[0], newData
-					} else {
-						s.Config.MutableData = newData
-					}
+				select {
+				case slots <- struct{}{}:
+				case <-genCtx.Done():
+					results <- evaluation{agents: batch, timedOut: true}
+					continue
 				}
-			case Closest:
-				if math.Abs(bestFitness-s.Config.ThresholdValue) < 0.0001 {
-					if newData, stop := s.Config.SuccessCallback(bestFitness, s.Config.MutableData); stop {
-						return s.Population[0], newData
-					} else {
-						s.Config.MutableData = newData
-					}
+				genomes := make([]*sometinyai.Genome, len(batch))
+				for i, agent := range batch {
+					genomes[i] = population[agent].Genome
+				}
+				e.run(batch, genomes, func(ev evaluation) { results <- ev })
+				<-slots
+			}
+		}()
+	}
+
+	fitness := make([]float64, len(s.Population))
+	for i := range fitness {
+		fitness[i] = s.worstFitness()
+	}
+	objectives := make([][]float64, len(s.Population))
+	behaviors := make([][]float64, len(s.Population))
+	finished := make([]bool, len(s.Population))
+	timedOut := 0
+wait:
+	for pending := len(batches); pending > 0; pending-- {
+		select {
+		case r := <-results:
+			if r.err != nil {
+				return r.err
+			}
+			if r.timedOut {
+				timedOut += len(r.agents)
+				continue
+			}
+			for i, agent := range r.agents {
+				if r.objectives != nil {
+					objectives[agent] = r.objectives[i]
 				}
+				fitness[agent] = r.fitness[i]
+				if r.behaviors != nil {
+					behaviors[agent] = r.behaviors[i]
+				}
+				finished[agent] = true
+			}
+		case <-genCtx.Done():
+			if err := ctx.Err(); err != nil {
+				return err
 			}
+			// The generation timed out, the rest keep the worst fitness
+			s.logger().Warn("generation timed out", "generation", s.Generation, "unfinished", pending)
+			break wait
+		}
+	}
+	if timedOut > 0 {
+		s.logger().Warn("agents timed out", "generation", s.Generation, "agents", timedOut)
+	}
+
+	for i := range s.Population {
+		s.Population[i].Fitness = fitness[i]
+		s.Population[i].Failed = !finished[i]
+		s.Population[i].Objectives = objectives[i]
+		s.Population[i].Behavior = behaviors[i]
+		if !finished[i] {
+			// Its evaluation may still be running, breeding gets a copy
+			s.Population[i].Genome = s.Population[i].Genome.Copy()
+			s.Population[i].Genome.SetRand(derive(s.rng))
+		}
+	}
+	return nil
+}
+
+// batches splits the indices of the population into the batches evaluate
+// hands to the workers: one agent each, or with FitnessBatch or Distributed
+// as many contiguous runs as there are workers.
+func (s *Simulation[D]) batches() [][]int {
+	size := 1
+	if s.Config.FitnessBatch != nil || s.Config.Coordinator != nil {
+		n := max(s.Config.Workers, 1)
+		size = (len(s.Population) + n - 1) / n
+	}
+	var batches [][]int
+	for start := 0; start < len(s.Population); start += size {
+		batch := make([]int, 0, size)
+		for i := start; i < min(start+size, len(s.Population)); i++ {
+			batch = append(batch, i)
+		}
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+func (s *Simulation[D]) logger() *slog.Logger {
+	if s.Config.Logger != nil {
+		return s.Config.Logger
+	}
+	return sometinyai.Logger()
+}
+
+// worstFitness is the fitness every other one is better than.
+func (s *Simulation[D]) worstFitness() float64 {
+	if s.Config.Threshold == Highest {
+		return math.Inf(-1)
+	}
+	return math.Inf(1)
+}
+
+// breed keeps the elites of the sorted population and fills the rest with
+// children of the survivors.
+func (s *Simulation[D]) breed() Population {
+	elite := s.elites(len(s.Population))
+	newPop := append(Population{}, s.Population[:elite]...)
+	parents := s.Population[:s.survivors(len(s.Population))]
+	for _, child := range s.children(parents, len(s.Population)-elite) {
+		newPop = append(newPop, Agent{Genome: child})
+	}
+	return newPop
+}
+
+// children breeds n mutated children of the sorted parents, picked by the
+// selector, by crossover or cloning.
+func (s *Simulation[D]) children(parents Population, n int) []*sometinyai.Genome {
+	children := make([]*sometinyai.Genome, n)
+	for i, a := range s.selectParents(parents, n) {
+		var child *sometinyai.Genome
+		if len(parents) > 1 && s.rng.Float64() < s.Config.CrossoverRate {
+			// The parents are sorted, the lower index is the fitter parent
+			b := s.mate(parents, a)
+			child = sometinyai.Crossover(
+				parents[a].Genome,
+				parents[b].Genome,
+				parents[min(a, b)].Genome,
+			)
+		} else {
+			child = parents[a].Genome.Copy()
 		}
+		child.SetRand(derive(s.rng))
+		child.Mutate(s.Config.MutationCount, s.mutation())
+		children[i] = child
+	}
+	return children
+}
+
+// derive returns a generator seeded from r, for a genome to use on its own.
+func derive(r *rand.Rand) *rand.Rand {
+	return rand.New(rand.NewPCG(r.Uint64(), r.Uint64()))
+}
 
-		fmt.Printf("Iteration %d | Fitness: %.4f | Data: %v\n",
-			iter, bestFitness, s.Config.MutableData)
+// reached reports whether the fitness reaches the threshold.
+func (s *Simulation[D]) reached(fitness float64) bool {
+	switch s.Config.Threshold {
+	case Lowest:
+		return fitness <= s.Config.ThresholdValue
+	case Closest:
+		return math.Abs(fitness-s.Config.ThresholdValue) < s.Config.Tolerance
+	default:
+		return fitness >= s.Config.ThresholdValue
 	}
+}
 
-	return s.Population[0], s.Config.MutableData
+// score maps a fitness value to one where higher is always better, according
+// to the threshold mode.
+func (s *Simulation[D]) score(fitness float64) float64 {
+	switch s.Config.Threshold {
+	case Lowest:
+		return -fitness
+	case Closest:
+		return -math.Abs(fitness - s.Config.ThresholdValue)
+	default:
+		return fitness
+	}
 }
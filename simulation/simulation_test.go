@@ -0,0 +1,113 @@
+package simulation
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/matwate/sometinyai"
+	"github.com/matwate/sometinyai/activation"
+)
+
+func TestSeedDeterministic(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{"one worker", []Option{Workers(1)}},
+		{"workers", []Option{Workers(8)}},
+		{"batch", []Option{Workers(3), FitnessBatch(func(genomes []*sometinyai.Genome, data interface{}) []float64 {
+			fitness := make([]float64, len(genomes))
+			for i, g := range genomes {
+				fitness[i] = xor(g, data)
+			}
+			return fitness
+		})}},
+		{"random fitness", []Option{Fitness(func(g *sometinyai.Genome, data interface{}) float64 {
+			return xor(g, data) + g.Rand().Float64()
+		})}},
+		{"crossover and speciation", []Option{CrossoverRate(0.5), Speciation(1)}},
+		{"tournament", []Option{Selection(Tournament{Size: 3})}},
+		{"objectives", []Option{Objectives(func(g *sometinyai.Genome, data interface{}) []float64 {
+			_, connections := g.Size()
+			return []float64{xor(g, data), float64(connections)}
+		}, Maximize, Minimize)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := func() Result[interface{}] {
+				opts := append([]Option{PopulationSize(30), Iterations(8), Seed(3), Fitness(xor)}, tt.opts...)
+				s := NewSimulation(2, 1, activation.Sigmoid, opts...)
+				res, err := s.TrainContext(context.Background())
+				if err != nil {
+					t.Fatal(err)
+				}
+				return res
+			}
+			a, b := run(), run()
+			if got, want := history(b.History), history(a.History); !reflect.DeepEqual(got, want) {
+				t.Errorf("second history\n%+v\nwant\n%+v", got, want)
+			}
+			ga, err := a.Best.Genome.ToProto()
+			if err != nil {
+				t.Fatal(err)
+			}
+			gb, err := b.Best.Genome.ToProto()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(ga, gb) {
+				t.Error("the best genomes differ")
+			}
+		})
+	}
+}
+
+func TestAgentTimeoutKeepsWorkers(t *testing.T) {
+	var running, peak, calls atomic.Int32
+	fitness := func(g *sometinyai.Genome, data interface{}) float64 {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		if calls.Add(1) <= 3 {
+			// Outlives its timeout and the generation
+			time.Sleep(50 * time.Millisecond)
+		}
+		return xor(g, data)
+	}
+	s := NewSimulation(2, 1, activation.Sigmoid, PopulationSize(8), Iterations(5), Workers(3),
+		AgentTimeout(5*time.Millisecond), Fitness(fitness))
+	res, err := s.TrainContext(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := peak.Load(); p > 3 {
+		t.Errorf("%d evaluations ran at once, want at most 3", p)
+	}
+	if res.History[0].Failed == 0 {
+		t.Error("no agent timed out")
+	}
+}
+
+func TestTypedOptionMismatch(t *testing.T) {
+	fitness := Fitness(func(*sometinyai.Genome, float64) float64 { return 0 })
+	s := NewSimulation(2, 1, activation.Sigmoid, fitness, PopulationSize(10), Iterations(1))
+	if _, err := s.TrainContext(context.Background()); err == nil || !strings.Contains(err.Error(), "float64") {
+		t.Fatalf("got error %v, want the mismatched type", err)
+	}
+
+	typed := NewTypedSimulation(2, 1, activation.Sigmoid, fitness, PopulationSize(10), Iterations(1))
+	if _, err := typed.TrainContext(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
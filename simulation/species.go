@@ -0,0 +1,204 @@
+package simulation
+
+import (
+	"math"
+	"slices"
+
+	"github.com/matwate/sometinyai"
+)
+
+// Species is a group of genomes close enough to compete only among
+// themselves, which protects new topologies while their weights get tuned.
+type Species struct {
+	ID             int
+	Representative *sometinyai.Genome // Members are compared against it
+	Members        Population         // Sorted best first
+	BestScore      float64            // Best score the species ever reached
+	Stagnation     int                // Generations since BestScore improved
+	Offspring      int                // Children allocated for the next generation
+}
+
+// Speciation splits the population into species, genomes further apart than
+// threshold belong to different ones. Fitness is shared inside each species
+// and offspring is allocated to species according to it.
+func Speciation(threshold float64) Option {
+	return func(o *Settings) { o.CompatibilityThreshold = threshold }
+}
+
+// CompatibilityCoefficients sets the weights of excess genes, disjoint genes
+// and average weight difference in the compatibility distance.
+func CompatibilityCoefficients(excess, disjoint, weight float64) Option {
+	return func(o *Settings) {
+		o.ExcessCoefficient = excess
+		o.DisjointCoefficient = disjoint
+		o.WeightCoefficient = weight
+	}
+}
+
+// StagnationLimit removes species whose best score has not improved for the
+// given number of generations. The species of the best genome is never
+// removed.
+func StagnationLimit(generations int) Option {
+	return func(o *Settings) { o.StagnationLimit = generations }
+}
+
+// CompatibilityDistance is the NEAT distance between two genomes: the number
+// of excess and disjoint genes, normalized by the size of the larger genome,
+// plus the average weight difference of the matching genes.
+func CompatibilityDistance(a, b *sometinyai.Genome, excess, disjoint, weight float64) float64 {
+	genesA, genesB := a.Genes(), b.Genes()
+	n := max(len(genesA), len(genesB), 1)
+
+	var excessCount, disjointCount, matching int
+	var weightDiff float64
+	i, j := 0, 0
+	for i < len(genesA) && j < len(genesB) {
+		switch {
+		case genesA[i].Innovation == genesB[j].Innovation:
+			weightDiff += math.Abs(genesA[i].Weight - genesB[j].Weight)
+			matching++
+			i++
+			j++
+		case genesA[i].Innovation < genesB[j].Innovation:
+			disjointCount++
+			i++
+		default:
+			disjointCount++
+			j++
+		}
+	}
+	excessCount = len(genesA) - i + len(genesB) - j
+
+	distance := (excess*float64(excessCount) + disjoint*float64(disjointCount)) / float64(n)
+	if matching > 0 {
+		distance += weight * weightDiff / float64(matching)
+	}
+	return distance
+}
+
+func (s *Simulation[D]) distance(a, b *sometinyai.Genome) float64 {
+	return CompatibilityDistance(a, b,
+		s.Config.ExcessCoefficient,
+		s.Config.DisjointCoefficient,
+		s.Config.WeightCoefficient,
+	)
+}
+
+// speciate assigns every agent of the sorted population to the first species
+// whose representative is close enough, creating species as needed.
+func (s *Simulation[D]) speciate() {
+	for _, sp := range s.Species {
+		sp.Members = nil
+	}
+	for i := range s.Population {
+		var home *Species
+		for _, sp := range s.Species {
+			if s.distance(s.Population[i].Genome, sp.Representative) < s.Config.CompatibilityThreshold {
+				home = sp
+				break
+			}
+		}
+		if home == nil {
+			home = &Species{
+				ID:             s.nextSpecies,
+				Representative: s.Population[i].Genome,
+				BestScore:      math.Inf(-1),
+			}
+			s.nextSpecies++
+			s.Species = append(s.Species, home)
+		}
+		home.Members = append(home.Members, s.Population[i])
+	}
+	s.Species = slices.DeleteFunc(s.Species, func(sp *Species) bool { return len(sp.Members) == 0 })
+}
+
+// breedSpecies speciates the sorted population, drops stagnant species and
+// breeds every remaining one in proportion to its shared fitness. Each
+// species keeps its champion and breeds the rest from its survivors.
+func (s *Simulation[D]) breedSpecies() Population {
+	s.speciate()
+
+	champion := s.Population[0].Genome
+	for _, sp := range s.Species {
+		if best := s.rankScore(sp.Members[0]); best > sp.BestScore {
+			sp.BestScore = best
+			sp.Stagnation = 0
+		} else {
+			sp.Stagnation++
+		}
+		// The next generation is compared against this one's best
+		sp.Representative = sp.Members[0].Genome
+	}
+	if s.Config.StagnationLimit > 0 {
+		s.Species = slices.DeleteFunc(s.Species, func(sp *Species) bool {
+			return sp.Stagnation > s.Config.StagnationLimit && sp.Representative != champion
+		})
+	}
+
+	// Explicit fitness sharing: shifted so the worst agent scores zero, and
+	// divided by the size of the species. Agents that failed to evaluate
+	// score zero too.
+	worst := math.Inf(1)
+	for _, agent := range s.Population {
+		if score := s.rankScore(agent); !math.IsInf(score, 0) {
+			worst = min(worst, score)
+		}
+	}
+	shares := make([]float64, len(s.Species))
+	var total float64
+	for i, sp := range s.Species {
+		for j := range sp.Members {
+			var shared float64
+			if score := s.rankScore(sp.Members[j]); !math.IsInf(score, 0) {
+				shared = (score - worst + 1e-9) / float64(len(sp.Members))
+			}
+			sp.Members[j].SharedFitness = shared
+			shares[i] += shared
+		}
+		total += shares[i]
+	}
+
+	// Largest remainder allocation of the offspring
+	size := len(s.Population)
+	if total == 0 {
+		// Nobody could be evaluated, every species gets the same share
+		for i := range shares {
+			shares[i] = 1
+		}
+		total = float64(len(shares))
+	}
+	remainders := make([]float64, len(s.Species))
+	allocated := 0
+	for i, sp := range s.Species {
+		exact := float64(size) * shares[i] / total
+		sp.Offspring = int(exact)
+		remainders[i] = exact - float64(sp.Offspring)
+		allocated += sp.Offspring
+	}
+	for ; allocated < size; allocated++ {
+		i := slices.Index(remainders, slices.Max(remainders))
+		s.Species[i].Offspring++
+		remainders[i] = -1
+	}
+
+	newPop := make(Population, 0, size)
+	for _, sp := range s.Species {
+		if sp.Offspring == 0 {
+			continue
+		}
+		newPop = append(newPop, sp.Members[0])
+		parents := sp.Members[:s.survivors(len(sp.Members))]
+		for _, child := range s.children(parents, sp.Offspring-1) {
+			newPop = append(newPop, Agent{Genome: child})
+		}
+	}
+
+	// Keep the champion in front, as the unspeciated breeding does
+	i := slices.IndexFunc(newPop, func(a Agent) bool { return a.Genome == champion })
+	if i > 0 {
+		newPop[0], newPop[i] = newPop[i], newPop[0]
+	} else if i < 0 {
+		newPop[0] = s.Population[0]
+	}
+	return newPop
+}
@@ -0,0 +1,192 @@
+package simulation
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/matwate/sometinyai"
+)
+
+// StagnationPolicy is what the simulation does once its best fitness has
+// stagnated, see Stagnation.
+type StagnationPolicy int
+
+const (
+	StopEarly     StagnationPolicy = iota // End the training
+	BoostMutation                         // Raise the mutation rates until the fitness improves, see MutationBoost
+	InjectFresh                           // Replace the worst agents with fresh random ones, see InjectFraction
+	Restart                               // Start over from fresh random agents and the hall of fame, see HallOfFame
+)
+
+func (p StagnationPolicy) String() string {
+	switch p {
+	case StopEarly:
+		return "stop"
+	case BoostMutation:
+		return "boost mutation"
+	case InjectFresh:
+		return "inject fresh"
+	case Restart:
+		return "restart"
+	}
+	return "unknown"
+}
+
+// Stagnation applies policy whenever the best fitness has not improved by
+// more than epsilon over the given number of generations, and again every
+// as many generations while it still does not. Generations where it
+// happened are marked in the history. Unlike StagnationLimit, it looks at
+// the whole population. It needs a fitness function, Objectives and Novelty
+// alone do not give one.
+func Stagnation(generations int, epsilon float64, policy StagnationPolicy) Option {
+	return func(o *Settings) {
+		o.StagnationGenerations = generations
+		o.StagnationEpsilon = epsilon
+		o.StagnationPolicy = policy
+	}
+}
+
+// MutationBoost sets the factor BoostMutation multiplies the mutation
+// probabilities and step size by, every time the fitness stagnates, 2 by
+// default. The rates stop growing at 16 times the configured ones, and
+// probabilities are capped at one.
+func MutationBoost(factor float64) Option {
+	return func(o *Settings) { o.MutationBoost = factor }
+}
+
+// InjectFraction sets the fraction of the population InjectFresh replaces, a
+// half by default, the last children first. The elites are kept, and with
+// Speciation the best agent of every species.
+func InjectFraction(f float64) Option {
+	return func(o *Settings) { o.InjectFraction = f }
+}
+
+// HallOfFame sets how many of the best agents ever are kept, and survive a
+// Restart, 5 by default. They are in Result.HallOfFame.
+func HallOfFame(size int) Option {
+	return func(o *Settings) { o.HallOfFameSize = size }
+}
+
+// maxBoost caps how much BoostMutation raises the mutation rates, so that
+// the step size does not grow without bound.
+const maxBoost = 16
+
+// stagnated records the best fitness of a generation, and reports whether it
+// has stagnated for long enough to apply the policy.
+func (s *Simulation[D]) stagnated(fitness float64) bool {
+	if score := s.score(fitness); score > s.plateauScore+s.Config.StagnationEpsilon {
+		s.plateauScore, s.plateau = score, 0
+		s.boost = 1
+		return false
+	}
+	s.plateau++
+	if s.plateau < s.Config.StagnationGenerations {
+		return false
+	}
+	s.plateau = 0
+	return true
+}
+
+// stagnate applies the stagnation policy to the bred population, and reports
+// whether to stop.
+func (s *Simulation[D]) stagnate() bool {
+	s.logger().Warn("fitness stagnated",
+		"generation", s.Generation,
+		"best", s.plateauScore,
+		"policy", s.Config.StagnationPolicy,
+	)
+	switch s.Config.StagnationPolicy {
+	case StopEarly:
+		return true
+	case BoostMutation:
+		s.boost = min(s.boost*s.Config.MutationBoost, maxBoost)
+	case InjectFresh:
+		n := int(float64(len(s.Population)) * s.Config.InjectFraction)
+		var replaced []int
+		for i := len(s.Population) - 1; i >= 0 && len(replaced) < n; i-- {
+			if !s.carried(i) {
+				replaced = append(replaced, i)
+			}
+		}
+		for k, agent := range s.fresh(len(replaced)) {
+			s.Population[replaced[k]] = agent
+		}
+	case Restart:
+		s.Population = s.fresh(s.Config.PopulationSize)
+		for i, agent := range s.hallOfFame[:min(len(s.hallOfFame), len(s.Population))] {
+			g := agent.Genome.Copy()
+			g.SetRand(derive(s.rng))
+			s.Population[i] = Agent{Genome: g}
+		}
+		s.Species = nil
+		s.boost = 1
+	}
+	return false
+}
+
+// carried reports whether the i-th agent of the bred population was carried
+// over unchanged: an elite, or the best agent of a species with Speciation.
+func (s *Simulation[D]) carried(i int) bool {
+	if s.Config.CompatibilityThreshold <= 0 {
+		return i < s.elites(len(s.Population))
+	}
+	// The champion is kept in front
+	if i == 0 {
+		return true
+	}
+	return slices.ContainsFunc(s.Species, func(sp *Species) bool {
+		return len(sp.Members) > 0 && sp.Members[0].Genome == s.Population[i].Genome
+	})
+}
+
+// mutation returns the mutation config of the children, raised by
+// BoostMutation.
+func (s *Simulation[D]) mutation() sometinyai.MutationConfig {
+	c := s.Config.Mutation
+	if s.boost <= 1 {
+		return c
+	}
+	for _, p := range []*float64{
+		&c.SplitConnection, &c.AddConnection, &c.ChangeWeight, &c.ChangeBias,
+		&c.ChangeActivation, &c.RemoveConnection, &c.RemoveNode,
+	} {
+		*p = min(*p*s.boost, 1)
+	}
+	c.Sigma *= s.boost
+	return c
+}
+
+// enterHallOfFame adds the best agents of the sorted generation to the hall
+// of fame, keeping the best ones ever.
+func (s *Simulation[D]) enterHallOfFame() {
+	size := s.Config.HallOfFameSize
+	for _, agent := range s.ranked {
+		if math.IsInf(s.score(agent.Fitness), 0) ||
+			slices.ContainsFunc(s.hallOfFame, func(a Agent) bool { return a.Genome == agent.Genome }) {
+			// Elites come back every generation
+			continue
+		}
+		s.hallOfFame = append(s.hallOfFame, agent)
+	}
+	slices.SortStableFunc(s.hallOfFame, func(a, b Agent) int {
+		return cmp.Compare(s.score(b.Fitness), s.score(a.Fitness))
+	})
+	s.hallOfFame = s.hallOfFame[:min(size, len(s.hallOfFame))]
+}
+
+// validateStagnation checks that there is a fitness to detect stagnation
+// on: Objectives and Novelty alone leave it NaN or zero.
+func (s *Simulation[D]) validateStagnation() error {
+	if s.Config.StagnationGenerations <= 0 ||
+		s.Config.Fitness != nil || s.Config.FitnessBatch != nil || s.Config.Coordinator != nil {
+		return nil
+	}
+	// Or every stage has its own
+	c := s.Config.Curriculum
+	if len(c) > 0 && !slices.ContainsFunc(c, func(stage Stage[D]) bool { return stage.Fitness == nil }) {
+		return nil
+	}
+	return errors.New("simulation: Stagnation needs a fitness function")
+}
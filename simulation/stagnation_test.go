@@ -0,0 +1,50 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/matwate/sometinyai/activation"
+)
+
+func TestInjectFreshKeepsCarried(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		species [][]int // Members of every species, by position in the population
+		kept    []int
+	}{
+		{"elites", []Option{Elitism(2)}, nil, []int{0, 1}},
+		{"no elites", []Option{Elitism(0)}, nil, nil},
+		{"species", []Option{Speciation(3)}, [][]int{{3, 4}, {7}}, []int{0, 3, 7}},
+		{"half", []Option{Elitism(2), InjectFraction(0.5)}, nil, []int{0, 1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{Fitness(xor), PopulationSize(10), Stagnation(1, 0, InjectFresh), InjectFraction(1)}, tt.opts...)
+			s := NewSimulation(2, 1, activation.Sigmoid, opts...)
+			for _, members := range tt.species {
+				sp := &Species{}
+				for _, i := range members {
+					sp.Members = append(sp.Members, s.Population[i])
+				}
+				s.Species = append(s.Species, sp)
+			}
+			before := make(Population, len(s.Population))
+			copy(before, s.Population)
+
+			if s.stagnate() {
+				t.Fatal("InjectFresh stopped the training")
+			}
+			for i := range s.Population {
+				kept := s.Population[i].Genome == before[i].Genome
+				want := false
+				for _, k := range tt.kept {
+					want = want || k == i
+				}
+				if kept != want {
+					t.Errorf("agent %d: kept %t, want %t", i, kept, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package simulation
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// MutableData sets the data every fitness function gets, and successCB,
+// called with the best fitness whenever it reaches the threshold, returns
+// the data of the next generation and whether to stop.
+func MutableData[D any](initialData D, successCB func(float64, D) (D, bool)) TypedOption[D] {
+	return typed("MutableData", func(o *Options[D]) {
+		o.MutableData = initialData
+		o.SuccessCallback = successCB
+	})
+}
+
+// TypedOption is an option that depends on the type D of the mutable data,
+// like Fitness. Every Option is also a TypedOption of any type, so typed
+// simulations take both, while a TypedOption of another type does not
+// compile. The reverse holds too: NewSimulation takes TypedOptions of any
+// type, and only TrainContext reports those whose type is not interface{}.
+type TypedOption[D any] func(*Settings)
+
+// typed returns an option setting fields of Options[D]. Options are applied
+// to the settings first, so it only gets set once they are part of
+// Options[D]. Given to an untyped simulation, the type can still be wrong,
+// which fails the training.
+func typed[D any](name string, set func(*Options[D])) TypedOption[D] {
+	return func(o *Settings) {
+		o.typed = append(o.typed, func(options any) error {
+			typed, ok := options.(*Options[D])
+			if !ok {
+				return fmt.Errorf("simulation: %s takes mutable data of type %v, not %v",
+					name, reflect.TypeFor[D](), options.(interface{ dataType() reflect.Type }).dataType())
+			}
+			set(typed)
+			return nil
+		})
+	}
+}
+
+// untyped converts the options of an untyped simulation, whose typed
+// options are only checked once it trains.
+func untyped(opts []Option) []TypedOption[interface{}] {
+	typed := make([]TypedOption[interface{}], len(opts))
+	for i, opt := range opts {
+		typed[i] = opt
+	}
+	return typed
+}
+
+// newOptions applies opts over settings.
+func newOptions[D any](settings Settings, opts []TypedOption[D]) *Options[D] {
+	o := &Options[D]{Settings: settings}
+	for _, opt := range opts {
+		opt(&o.Settings)
+	}
+	for _, set := range o.typed {
+		if err := set(o); err != nil && o.err == nil {
+			o.err = err
+		}
+	}
+	o.typed = nil
+	return o
+}
+
+func (o *Options[D]) dataType() reflect.Type {
+	return reflect.TypeFor[D]()
+}